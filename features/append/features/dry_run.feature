@@ -0,0 +1,30 @@
+Feature: dry run
+
+  Background:
+    Given the current branch is a feature branch "existing"
+    And the commits
+      | BRANCH   | LOCATION      | MESSAGE         |
+      | existing | local, origin | existing commit |
+    And an uncommitted file
+    When I run "git-town append new --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH   | COMMAND                             |
+      | existing | git fetch --prune --tags            |
+      |          | git add -A                          |
+      |          | git stash                           |
+      |          | git checkout main                   |
+      | main     | git rebase origin/main              |
+      |          | git push                            |
+      |          | git checkout existing               |
+      | existing | git merge --no-edit origin/existing |
+      |          | git merge --no-edit main            |
+      |          | git push                            |
+      |          | git branch new existing             |
+      |          | git checkout new                    |
+      | new      | git stash pop                       |
+    And the current branch is still "existing"
+    And the uncommitted file still exists
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
@@ -0,0 +1,30 @@
+Feature: display the branch hierarchy and the status of each branch
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a local feature branch "gamma"
+    And a feature branch "delta"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      |
+      | main   | local         | main commit  |
+      | alpha  | local, origin | alpha commit |
+      | beta   | local         | beta commit  |
+    And origin deletes the "delta" branch
+    And I ran "git fetch --prune"
+    And the current branch is "beta"
+    When I run "git-town branches"
+
+  Scenario: result
+    Then it runs no commands
+    And it prints:
+      """
+      main  1 ahead of origin/main
+        alpha  1 ahead and 1 behind main | in sync with origin/alpha
+          beta  1 ahead and 1 behind alpha | 1 ahead of origin/beta
+        delta  1 behind main | tracking branch deleted
+        gamma  1 behind main | local only
+      """
+    And the current branch is still "beta"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
@@ -0,0 +1,47 @@
+Feature: display the API tokens and where they come from
+
+  Scenario: no tokens
+    When I run "git-town config tokens"
+    Then it prints:
+      """
+      API tokens:
+        Azure DevOps token: (not set)
+        Bitbucket token: (not set)
+        GitHub token: (not set)
+        GitLab token: (not set)
+        Gitea token: (not set)
+      """
+
+  Scenario: token in the Git Town configuration
+    Given global setting "github-token" is "ghp_1234567890abcdef"
+    When I run "git-town config tokens"
+    Then it prints:
+      """
+        GitHub token: ********cdef (from Git configuration git-town.github-token)
+      """
+
+  Scenario: token in the environment
+    When I run "git-town config tokens" with "GITLAB_TOKEN=glpat-1234567890abcdef" in the environment
+    Then it prints:
+      """
+        GitLab token: ********cdef (from environment variable GITLAB_TOKEN)
+      """
+
+  Scenario: token for the hostname of the origin remote
+    Given the origin is "git@gitlab.example.com:git-town/git-town.git"
+    And setting "code-hosting-driver" is "gitlab"
+    And global setting "gitlab-token" is "glpat-global-1111"
+    And local Git setting "git-town-host.gitlab.example.com.gitlab-token" is "glpat-host-2222"
+    When I run "git-town config tokens"
+    Then it prints:
+      """
+        GitLab token: ********2222 (from Git configuration git-town-host.gitlab.example.com.gitlab-token)
+      """
+
+  Scenario: the Git Town configuration takes precedence over the environment
+    Given global setting "gitlab-token" is "glpat-global-1111"
+    When I run "git-town config tokens" with "GITLAB_TOKEN=glpat-environment-2222" in the environment
+    Then it prints:
+      """
+        GitLab token: ********1111 (from Git configuration git-town.gitlab-token)
+      """
@@ -16,11 +16,17 @@ Feature: show the configuration
         run pre-push hook: yes
         push new branches: no
         ship removes the remote branch: yes
+        ship message template: (not set)
+        ship strategy: squash
+        squash author mode: choose
         sync strategy: merge
         sync with upstream: yes
 
       Hosting:
         hosting service override: (not set)
+        Azure DevOps token: (not set)
+        Bitbucket token: (not set)
+        GitHub API URL: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
@@ -44,11 +50,17 @@ Feature: show the configuration
         run pre-push hook: yes
         push new branches: no
         ship removes the remote branch: yes
+        ship message template: (not set)
+        ship strategy: squash
+        squash author mode: choose
         sync strategy: merge
         sync with upstream: yes
 
       Hosting:
         hosting service override: (not set)
+        Azure DevOps token: (not set)
+        Bitbucket token: (not set)
+        GitHub API URL: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
@@ -78,11 +90,17 @@ Feature: show the configuration
         run pre-push hook: yes
         push new branches: no
         ship removes the remote branch: yes
+        ship message template: (not set)
+        ship strategy: squash
+        squash author mode: choose
         sync strategy: merge
         sync with upstream: yes
 
       Hosting:
         hosting service override: (not set)
+        Azure DevOps token: (not set)
+        Bitbucket token: (not set)
+        GitHub API URL: (not set)
         GitHub token: (not set)
         GitLab token: (not set)
         Gitea token: (not set)
@@ -0,0 +1,35 @@
+Feature: dry run
+
+  Background:
+    Given the commits
+      | BRANCH | LOCATION | MESSAGE     |
+      | main   | origin   | main commit |
+    And the current branch is "main"
+    And setting "push-new-branches" is "true"
+    And an uncommitted file
+    When I run "git-town hack new --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | main   | git fetch --prune --tags |
+      |        | git add -A               |
+      |        | git stash                |
+      |        | git rebase origin/main   |
+      |        | git push                 |
+      |        | git branch new main      |
+      |        | git checkout new         |
+      | new    | git push -u origin new   |
+      |        | git stash pop            |
+    And the current branch is still "main"
+    And the uncommitted file still exists
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs no commands
+    And it prints the error:
+      """
+      nothing to undo
+      """
@@ -12,6 +12,7 @@ Feature: help for commands
       | COMMAND                     |
       | aliases                     |
       | append                      |
+      | branches                    |
       | completions                 |
       | config                      |
       | config main-branch          |
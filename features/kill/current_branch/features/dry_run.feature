@@ -0,0 +1,25 @@
+Feature: dry run
+
+  Background:
+    Given the current branch is a feature branch "current"
+    And a feature branch "other"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | current | local, origin | current commit |
+      | other   | local, origin | other commit   |
+    And an uncommitted file
+    When I run "git-town kill --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                        |
+      | current | git fetch --prune --tags       |
+      |         | git push origin :current       |
+      |         | git add -A                     |
+      |         | git commit -m "WIP on current" |
+      |         | git checkout main              |
+      | main    | git branch -D current          |
+    And the current branch is still "current"
+    And the uncommitted file still exists
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
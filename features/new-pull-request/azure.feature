@@ -0,0 +1,18 @@
+@skipWindows
+Feature: Azure DevOps support
+
+  Scenario Outline:
+    Given the current branch is a feature branch "feature"
+    And the origin is "<ORIGIN>"
+    And tool "open" is installed
+    When I run "git-town new-pull-request"
+    Then "open" launches a new pull request with this url in my browser:
+      """
+      https://dev.azure.com/git-town/git-town/_git/git-town/pullrequestcreate?sourceRef=feature&targetRef=main
+      """
+
+    Examples:
+      | ORIGIN                                                         |
+      | https://dev.azure.com/git-town/git-town/_git/git-town          |
+      | https://git-town@dev.azure.com/git-town/git-town/_git/git-town |
+      | git@ssh.dev.azure.com:v3/git-town/git-town/git-town            |
@@ -0,0 +1,16 @@
+Feature: does not create proposals for a stack starting at the main branch
+
+  Background:
+    Given the origin is "git@github.com:git-town/git-town"
+    And the current branch is "main"
+    When I run "git-town new-pull-request --stack"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | main   | git fetch --prune --tags |
+    And it prints the error:
+      """
+      the branch "main" is not a feature branch. Only feature branches can be part of a stack
+      """
+    And the current branch is still "main"
@@ -0,0 +1,27 @@
+@skipWindows
+Feature: dry run
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE              |
+      | feature | local    | local feature commit |
+    And tool "open" is installed
+    And the origin is "git@github.com:git-town/git-town.git"
+    When I run "git-town new-pull-request --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                            |
+      | feature | git fetch --prune --tags                                           |
+      |         | git checkout main                                                  |
+      | main    | git rebase origin/main                                             |
+      |         | git push                                                           |
+      |         | git checkout feature                                               |
+      | feature | git merge --no-edit origin/feature                                 |
+      |         | git merge --no-edit main                                           |
+      |         | git push                                                           |
+      | <none>  | open https://github.com/git-town/git-town/compare/feature?expand=1 |
+    And the current branch is still "feature"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
@@ -0,0 +1,40 @@
+Feature: create pull requests for a lineage that ends at a branch with several children
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And a feature branch "gamma" as a child of "beta"
+    And a feature branch "delta" as a child of "beta"
+    And the current branch is "alpha"
+    And a GitHub pull request for branch "alpha" with title "alpha"
+    When I run "git-town new-pull-request --stack"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                                          |
+      | alpha  | git fetch --prune --tags                         |
+      |        | git checkout main                                |
+      | main   | git rebase origin/main                           |
+      |        | git checkout alpha                               |
+      | alpha  | git merge --no-edit origin/alpha                 |
+      |        | git merge --no-edit main                         |
+      |        | git checkout beta                                |
+      | beta   | git merge --no-edit origin/beta                  |
+      |        | git merge --no-edit alpha                        |
+      |        | git checkout alpha                               |
+      | <none> | GitHub API: creating PR from "beta" into "alpha" |
+      |        | GitHub API: updating description of PR #1        |
+      |        | GitHub API: updating description of PR #2        |
+    And the current branch is still "alpha"
+    And the GitHub pull requests are now
+      | NUMBER | BRANCH | TARGET | TITLE |
+      | 1      | alpha  | main   | alpha |
+      | 2      | beta   | alpha  | beta  |
+    And the body of GitHub pull request #1 is now:
+      """
+      <!-- git-town stack start -->
+      This is part of a stack:
+      1. **alpha** (this one)
+      2. [beta](https://github.com/git-town/git-town/pull/2)
+      <!-- git-town stack end -->
+      """
@@ -0,0 +1,24 @@
+Feature: dry run
+
+  Background:
+    Given the feature branches "active" and "old"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE       |
+      | active | local, origin | active commit |
+      | old    | local, origin | old commit    |
+    And origin deletes the "old" branch
+    And I ran "git fetch --prune"
+    And the current branch is "old"
+    When I run "git-town prune-branches --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | old    | git fetch --prune --tags |
+      |        | git checkout main        |
+      | main   | git branch -D old        |
+    And the current branch is still "old"
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | active | main   |
+      | old    | main   |
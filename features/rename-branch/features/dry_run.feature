@@ -0,0 +1,21 @@
+Feature: dry run
+
+  Background:
+    Given the current branch is a feature branch "old"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE    |
+      | old    | local, origin | old commit |
+    When I run "git-town rename-branch new --dry-run"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                  |
+      | old    | git fetch --prune --tags |
+      |        | git branch new old       |
+      |        | git checkout new         |
+      | new    | git push -u origin new   |
+      |        | git push origin :old     |
+      |        | git branch -D old        |
+    And the current branch is still "old"
+    And now the initial commits exist
+    And the initial branches and hierarchy exist
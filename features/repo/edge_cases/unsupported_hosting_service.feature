@@ -7,7 +7,9 @@ Feature: unsupported hosting service
       unsupported hosting service
 
       This command requires hosting on one of these services:
+      * Azure DevOps
       * Bitbucket
+      * Bitbucket Server
       * GitHub
       * GitLab
       * Gitea
@@ -0,0 +1,51 @@
+Feature: prevent concurrent Git Town commands in the same repository
+
+  Background:
+    Given the current branch is a feature branch "feature"
+
+  Scenario: another Git Town command is running
+    Given another Git Town process runs "git town sync"
+    When I run "git-town sync"
+    Then it runs no commands
+    And it prints the error:
+      """
+      another Git Town command ("git town sync") is running in this repository
+      """
+
+  Scenario: continuing while another Git Town command is running
+    Given another Git Town process runs "git town sync"
+    When I run "git-town continue"
+    Then it runs no commands
+    And it prints the error:
+      """
+      another Git Town command ("git town sync") is running in this repository
+      """
+
+  Scenario: stale lock
+    Given a Git Town process that doesn't run anymore left its lock behind
+    When I run "git-town sync"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+
+  @skipWindows
+  Scenario: wait for the other Git Town command to finish
+    Given another Git Town process runs "git town sync" for 1 second
+    When I run "git-town sync --wait"
+    Then it prints something like:
+      """
+      Waiting for "git town sync" \(process \d+ on .*\) to finish ...
+      """
+    And it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
@@ -14,6 +14,7 @@ Feature: continue after successful command
       |                      |
       | aliases true         |
       | append new           |
+      | branches             |
       | completions fish     |
       | config               |
       | diff-parent          |
@@ -13,6 +13,7 @@ Feature: require minimum Git version
       |                      |
       | aliases true         |
       | append               |
+      | branches             |
       | config               |
       | diff-parent          |
       | hack                 |
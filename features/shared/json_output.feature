@@ -0,0 +1,54 @@
+Feature: machine-readable output
+
+  Scenario: successful command
+    Given the current branch is "main"
+    When I run "git-town hack new --output=json"
+    Then it prints:
+      """
+      {"args":["new"],"command":"hack","event":"started"}
+      """
+    And it prints:
+      """
+      {"event":"step","step":{"data":{"Branch":"new","StartingPoint":"main"},"type":"*CreateBranchStep"}}
+      {"args":["branch","new","main"],"branch":"main","event":"subprocess","executable":"git","exitCode":0,"output":""}
+      """
+    And it prints:
+      """
+      {"command":"hack","event":"finished"}
+      """
+    And the current branch is now "new"
+
+  Scenario: command that hits a conflict
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    When I run "git-town sync --output=json"
+    Then it prints something like:
+      """
+      {"args":\["merge","--no-edit","main"\],"branch":"feature","event":"subprocess","executable":"git","exitCode":1,"output":".*CONFLICT.*"}
+      """
+    And it prints the error:
+      """
+      "event":"failed","unfinished":{"canSkip":true,"command":"sync","endBranch":"feature","endTime":
+      """
+    And the current branch is still "feature"
+
+  @skipWindows
+  Scenario: prompt that cannot be answered
+    Given Git Town is not configured
+    When I run "git-town hack new --output=json"
+    Then it prints the error:
+      """
+      {"event":"prompt-unanswered","input":"the main branch via \"git town config main-branch \u003cbranch\u003e\"","options":["main"],"prompt":"Please specify the main development branch:"}
+      {"command":"hack","error":"missing input: please provide the main branch via \"git town config main-branch \u003cbranch\u003e\"","event":"failed"}
+      """
+    And the current branch is still "main"
+
+  Scenario: unknown output format
+    When I run "git-town hack new --output=zonk"
+    Then it prints the error:
+      """
+      unknown output format "zonk", please use "text" or "json"
+      """
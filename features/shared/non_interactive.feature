@@ -0,0 +1,52 @@
+Feature: non-interactive mode
+
+  Scenario: missing configuration
+    Given Git Town is not configured
+    When I run "git-town hack new --non-interactive"
+    Then it prints the error:
+      """
+      missing input: please provide the main branch via "git town config main-branch <branch>"
+      """
+    And the current branch is still "main"
+
+  Scenario: enabled via the environment
+    Given Git Town is not configured
+    When I run "git-town hack new" with "GIT_TOWN_NONINTERACTIVE=1" in the environment
+    Then it prints the error:
+      """
+      missing input: please provide the main branch via "git town config main-branch <branch>"
+      """
+    And the current branch is still "main"
+
+  Scenario: unknown parent branch
+    Given the current branch is "existing"
+    When I run "git-town sync --non-interactive"
+    Then it prints the error:
+      """
+      missing input: please provide the parent branch of "existing" via "--parent"
+      """
+    And the current branch is still "existing"
+    And no branch hierarchy exists now
+
+  Scenario: parent branch provided
+    Given the current branch is a feature branch "existing"
+    When I run "git-town hack -p new --non-interactive --parent existing"
+    Then it runs the commands
+      | BRANCH   | COMMAND                     |
+      | existing | git fetch --prune --tags            |
+      |          | git merge --no-edit origin/existing |
+      |          | git merge --no-edit main            |
+      |          | git branch new existing             |
+      |          | git checkout new                    |
+    And the current branch is now "new"
+    And this branch hierarchy exists now
+      | BRANCH   | PARENT   |
+      | existing | main     |
+      | new      | existing |
+
+  Scenario: invalid response for unfinished commands
+    When I run "git-town sync --on-unfinished=zonk"
+    Then it prints the error:
+      """
+      unknown value for --on-unfinished: "zonk", please use one of continue, abort, discard, skip
+      """
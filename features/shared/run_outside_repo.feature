@@ -14,6 +14,7 @@ Feature: require a Git repository
       | git-town config main-branch        |
       | git-town config perennial-branches |
       | git-town config sync-strategy      |
+      | git-town config tokens             |
       | git-town hack feature              |
       | git-town kill                      |
       | git-town new-pull-request          |
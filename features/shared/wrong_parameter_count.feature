@@ -22,6 +22,7 @@ Feature: too few or many parameters
       | config perennial-branches arg1        |
       | config perennial-branches update arg1 |
       | config pull-branch-strategy arg1 arg2 |
+      | config tokens arg1                    |
       | hack                                  |
       | hack arg1 arg2                        |
       | kill arg1 arg2                        |
@@ -32,7 +33,6 @@ Feature: too few or many parameters
       | rename-branch arg1 arg2 arg3          |
       | repo arg1                             |
       | set-parent arg1                       |
-      | ship arg1 arg2                        |
       | sync arg1                             |
       | version arg1                          |
 
@@ -0,0 +1,26 @@
+Feature: shipping automatically requires a proposal that Git Town can merge via the API
+
+  Scenario: no proposal
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    When I run "git-town ship --auto"
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | feature | git fetch --prune --tags |
+    And it prints the error:
+      """
+      cannot merge branch "feature" automatically because it has no proposal that Git Town can merge via the API of your code hosting service
+      """
+    And the current branch is still "feature"
+    And now the initial commits exist
+
+  Scenario: combined with --stack
+    Given the current branch is a feature branch "feature"
+    When I run "git-town ship --auto --stack"
+    Then it runs no commands
+    And it prints the error:
+      """
+      the --auto flag cannot be combined with --stack because the code hosting service merges each proposal on its own
+      """
@@ -0,0 +1,24 @@
+Feature: ship strategies that keep the original commits don't use a commit message
+
+  Scenario: commit message via CLI
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    When I run "git-town ship --strategy=fast-forward -m 'feature done'"
+    Then it runs no commands
+    And it prints the error:
+      """
+      the --message flag doesn't apply to the "fast-forward" ship strategy because it keeps the original commits
+      """
+    And the current branch is still "feature"
+    And now the initial commits exist
+
+  Scenario: unknown ship strategy
+    Given the current branch is a feature branch "feature"
+    When I run "git-town ship --strategy=zonk"
+    Then it runs no commands
+    And it prints the error:
+      """
+      unknown ship strategy: "zonk"
+      """
@@ -0,0 +1,88 @@
+Feature: handle conflicts while shipping a stack
+
+  Background:
+    Given a feature branch "alpha"
+    And a feature branch "beta" as a child of "alpha"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE                 | FILE NAME        | FILE CONTENT  |
+      | main   | local, origin | conflicting main commit | conflicting_file | main content  |
+      | alpha  | local, origin | alpha commit            | alpha_file       | alpha content |
+      | beta   | local, origin | conflicting beta commit | conflicting_file | beta content  |
+    And the current branch is "beta"
+    When I run "git-town ship --stack" and enter these commit messages:
+      | MESSAGE    |
+      | alpha done |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | beta   | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash alpha         |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git branch -D alpha              |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit main         |
+    And it prints the error:
+      """
+      To abort, run "git-town abort".
+      To continue after having resolved conflicts, run "git-town continue".
+      """
+    And the current branch is now "beta"
+    And a merge is now in progress
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                     |
+      | beta   | git merge --abort                                           |
+      |        | git checkout main                                           |
+      | main   | git branch alpha {{ sha 'Merge branch 'main' into alpha' }} |
+      |        | git revert {{ sha 'alpha done' }}                           |
+      |        | git push                                                    |
+      |        | git checkout alpha                                          |
+      | alpha  | git reset --hard {{ sha 'alpha commit' }}                   |
+      |        | git checkout main                                           |
+      | main   | git checkout beta                                           |
+    And the current branch is now "beta"
+    And no merge is in progress
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                 |
+      | main   | local, origin | conflicting main commit |
+      |        |               | alpha done              |
+      |        |               | Revert "alpha done"     |
+      | alpha  | local, origin | alpha commit            |
+      | beta   | local, origin | conflicting beta commit |
+    And the initial branches and hierarchy exist
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and enter "beta done" for the commit message
+    Then it runs the commands
+      | BRANCH | COMMAND                 |
+      | beta   | git commit --no-edit    |
+      |        | git checkout main       |
+      | main   | git merge --squash beta |
+      |        | git commit              |
+      |        | git push                |
+      |        | git push origin :beta   |
+      |        | git branch -D beta      |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY | BRANCHES    |
+      | local      | main        |
+      | origin     | main, alpha |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                 | FILE NAME        | FILE CONTENT     |
+      | main   | local, origin | conflicting main commit | conflicting_file | main content     |
+      |        |               | alpha done              | alpha_file       | alpha content    |
+      |        |               | beta done               | conflicting_file | resolved content |
+      | alpha  | origin        | alpha commit            | alpha_file       | alpha content    |
+    And no branch hierarchy exists now
@@ -0,0 +1,31 @@
+Feature: ship a feature branch in a repo with an upstream remote
+
+  Background:
+    Given an upstream repo
+    And the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+
+  Scenario: result
+    When I run "git-town ship -m done"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git fetch upstream main            |
+      |         | git rebase upstream/main           |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+      |         | git checkout main                  |
+      | main    | git merge --squash feature         |
+      |         | git commit -m done                 |
+      |         | git push                           |
+      |         | git push origin :feature           |
+      |         | git branch -D feature              |
+    And the current branch is now "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE |
+      | main   | local, origin | done    |
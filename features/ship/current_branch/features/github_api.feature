@@ -0,0 +1,60 @@
+Feature: ship the current feature branch via the GitHub API
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    And a GitHub pull request for branch "feature" with title "my feature"
+    When I run "git-town ship -m 'feature done' --ignore-checks"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+      |         | git checkout main                  |
+      | <none>  | GitHub API: merging PR #1          |
+      | main    | git pull                           |
+      |         | git push origin :feature           |
+      |         | git branch -D feature              |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY    | BRANCHES |
+      | local, origin | main     |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      |
+      | main   | local, origin | feature done |
+    And no branch hierarchy exists now
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                            |
+      | main    | git branch feature {{ sha 'feature commit' }}      |
+      |         | git push -u origin feature                         |
+      |         | git revert {{ sha 'feature done' }}                |
+      | <none>  | GitHub API: creating PR from "feature" into "main" |
+      | main    | git push                                           |
+      |         | git checkout feature                               |
+      | feature | git checkout main                                  |
+      | main    | git checkout feature                               |
+    And it prints:
+      """
+      GitHub doesn't allow reopening merged proposals, proposal #1 stays merged.
+      """
+    And it prints:
+      """
+      Created proposal #2 for branch "feature" instead: https://github.com/git-town/git-town/pull/2
+      """
+    And the current branch is now "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE               |
+      | main    | local, origin | feature done          |
+      |         |               | Revert "feature done" |
+      | feature | local, origin | feature commit        |
+    And the initial branches and hierarchy exist
@@ -0,0 +1,68 @@
+Feature: ship a feature branch of a fork via the GitHub API
+
+  Background:
+    Given an upstream repo
+    And the origin is a fork of the upstream repo on GitHub
+    And the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    And a GitHub pull request for branch "feature" with title "my feature"
+    When I run "git-town ship -m 'feature done' --ignore-checks"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | feature | git fetch --prune --tags           |
+      |         | git checkout main                  |
+      | main    | git rebase origin/main             |
+      |         | git fetch upstream main            |
+      |         | git rebase upstream/main           |
+      |         | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+      |         | git checkout main                  |
+      | <none>  | GitHub API: merging PR #1          |
+      | main    | git fetch upstream main            |
+      |         | git rebase upstream/main           |
+      |         | git push                           |
+      |         | git push origin :feature           |
+      |         | git branch -D feature              |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY    | BRANCHES |
+      | local, origin | main     |
+    And now these commits exist
+      | BRANCH | LOCATION                | MESSAGE      |
+      | main   | local, origin, upstream | feature done |
+    And no branch hierarchy exists now
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                            |
+      | main    | git branch feature {{ sha 'feature commit' }}      |
+      |         | git push -u origin feature                         |
+      |         | git reset --hard {{ sha 'Initial commit' }}        |
+      |         | git merge --ff-only {{ sha 'feature done' }}       |
+      |         | git revert {{ sha 'feature done' }}                |
+      | <none>  | GitHub API: creating PR from "feature" into "main" |
+      | main    | git push                                           |
+      |         | git checkout feature                               |
+      | feature | git checkout main                                  |
+      | main    | git checkout feature                               |
+    And it prints:
+      """
+      Created proposal #2 for branch "feature" instead: https://github.com/git-town/git-town/pull/2
+      """
+    And the current branch is now "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION                | MESSAGE               |
+      | main    | local, origin, upstream | feature done          |
+      |         | local, origin           | Revert "feature done" |
+      | feature | local, origin           | feature commit        |
+    And the initial branches and hierarchy exist
+    And the GitHub pull requests are now
+      | NUMBER | BRANCH  | TARGET | TITLE      |
+      | 1      | feature | main   | my feature |
+      | 2      | feature | main   | my feature |
@@ -0,0 +1,30 @@
+Feature: ship with a merge commit non-interactively
+
+  Background:
+    Given setting "ship-strategy" is "merge"
+    And the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION      | MESSAGE        |
+      | feature | local, origin | feature commit |
+    When I run "git-town ship --non-interactive"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                             |
+      | feature | git fetch --prune --tags            |
+      |         | git checkout main                   |
+      | main    | git rebase origin/main              |
+      |         | git checkout feature                |
+      | feature | git merge --no-edit origin/feature  |
+      |         | git merge --no-edit main            |
+      |         | git checkout main                   |
+      | main    | git merge --no-ff --no-edit feature |
+      |         | git push                            |
+      |         | git push origin :feature            |
+      |         | git branch -d feature               |
+    And the current branch is now "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                |
+      | main   | local, origin | feature commit         |
+      |        |               | Merge branch 'feature' |
+    And no branch hierarchy exists now
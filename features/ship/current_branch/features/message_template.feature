@@ -0,0 +1,57 @@
+Feature: pre-fill the commit message of shipped branches from a template
+
+  Background:
+    Given the current branch is a feature branch "ABC-123-login"
+    And the commits
+      | BRANCH        | LOCATION | MESSAGE           |
+      | ABC-123-login | local    | add login form    |
+      |               |          | validate password |
+    And setting "ship-message-template" is:
+      """
+      {{issues}}: {{branch}}
+
+      {{commits}}
+      """
+
+  Scenario: keep the pre-filled commit message
+    When I run "git-town ship" and close the editor
+    Then it runs the commands
+      | BRANCH        | COMMAND                                  |
+      | ABC-123-login | git fetch --prune --tags                 |
+      |               | git checkout main                        |
+      | main          | git rebase origin/main                   |
+      |               | git checkout ABC-123-login               |
+      | ABC-123-login | git merge --no-edit origin/ABC-123-login |
+      |               | git merge --no-edit main                 |
+      |               | git checkout main                        |
+      | main          | git merge --squash ABC-123-login         |
+      |               | git commit                               |
+      |               | git push                                 |
+      |               | git push origin :ABC-123-login           |
+      |               | git branch -D ABC-123-login              |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                |
+      | main   | local, origin | ABC-123: ABC-123-login |
+    And the last commit on the "main" branch has the message:
+      """
+      ABC-123: ABC-123-login
+
+      - add login form
+      - validate password
+      """
+
+  Scenario: provide the commit message via the CLI
+    When I run "git-town ship -m 'login done'"
+    Then now these commits exist
+      | BRANCH | LOCATION      | MESSAGE    |
+      | main   | local, origin | login done |
+
+  Scenario: unknown placeholder
+    Given setting "ship-message-template" is "{{title}}"
+    When I run "git-town ship"
+    Then it runs no commands
+    And it prints the error:
+      """
+      unknown placeholder "{{title}}" in the ship message template, the known placeholders are: branch, co-authors, commits, issues, proposal-body, proposal-number, proposal-title
+      """
+    And the current branch is still "ABC-123-login"
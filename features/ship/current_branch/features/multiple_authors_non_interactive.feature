@@ -0,0 +1,25 @@
+Feature: ship a coworker's feature branch non-interactively
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE            | AUTHOR                            |
+      | feature | local    | developer commit 1 | developer <developer@example.com> |
+      |         |          | developer commit 2 | developer <developer@example.com> |
+      |         |          | coworker commit    | coworker <coworker@example.com>   |
+
+  Scenario: author provided
+    When I run "git-town ship -m 'feature done' --non-interactive --squash-author 'coworker <coworker@example.com>'"
+    Then now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      | AUTHOR                          |
+      | main   | local, origin | feature done | coworker <coworker@example.com> |
+    And no branch hierarchy exists now
+
+  Scenario: author missing
+    When I run "git-town ship -m 'feature done' --non-interactive"
+    Then it prints the error:
+      """
+      missing input: please provide the author via "--squash-author"
+      """
+    And the current branch is still "feature"
+    And the initial branches and hierarchy exist
@@ -0,0 +1,123 @@
+@skipWindows
+Feature: credit all authors of a shipped branch via co-author trailers
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE           | AUTHOR                          |
+      | feature | local    | coworker commit 1 | coworker <coworker@example.com> |
+      |         |          | my commit         | user <email@example.com>        |
+      |         |          | coworker commit 2 | coworker <coworker@home.com>    |
+    And the mailmap contains:
+      """
+      coworker <coworker@example.com> <coworker@home.com>
+      """
+
+  Scenario: the branch owner authors the squash commit
+    Given setting "squash-author-mode" is "branch-owner"
+    When I run "git-town ship -m 'feature done'"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                                                                                      |
+      | feature | git fetch --prune --tags                                                                                                     |
+      |         | git checkout main                                                                                                            |
+      | main    | git rebase origin/main                                                                                                       |
+      |         | git checkout feature                                                                                                         |
+      | feature | git merge --no-edit origin/feature                                                                                           |
+      |         | git merge --no-edit main                                                                                                     |
+      |         | git checkout main                                                                                                            |
+      | main    | git merge --squash feature                                                                                                   |
+      |         | git commit -m "feature done" --author "coworker <coworker@example.com>" --trailer "Co-authored-by: user <email@example.com>" |
+      |         | git push                                                                                                                     |
+      |         | git push origin :feature                                                                                                     |
+      |         | git branch -D feature                                                                                                        |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      | AUTHOR                          |
+      | main   | local, origin | feature done | coworker <coworker@example.com> |
+    And the last commit on the "main" branch has the trailers:
+      """
+      Co-authored-by: user <email@example.com>
+      """
+    And no branch hierarchy exists now
+
+  Scenario: the current user authors the squash commit
+    When I run "git-town ship -m 'feature done' --squash-author-mode=current-user"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                                                  |
+      | feature | git fetch --prune --tags                                                                 |
+      |         | git checkout main                                                                        |
+      | main    | git rebase origin/main                                                                   |
+      |         | git checkout feature                                                                     |
+      | feature | git merge --no-edit origin/feature                                                       |
+      |         | git merge --no-edit main                                                                 |
+      |         | git checkout main                                                                        |
+      | main    | git merge --squash feature                                                               |
+      |         | git commit -m "feature done" --trailer "Co-authored-by: coworker <coworker@example.com>" |
+      |         | git push                                                                                 |
+      |         | git push origin :feature                                                                 |
+      |         | git branch -D feature                                                                    |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      | AUTHOR                   |
+      | main   | local, origin | feature done | user <email@example.com> |
+    And the last commit on the "main" branch has the trailers:
+      """
+      Co-authored-by: coworker <coworker@example.com>
+      """
+    And no branch hierarchy exists now
+
+  Scenario: enter the commit message in the editor
+    Given setting "squash-author-mode" is "current-user"
+    When I run "git-town ship" and enter "feature done" for the commit message
+    Then it runs the commands
+      | BRANCH  | COMMAND                                                                                  |
+      | feature | git fetch --prune --tags                                                                 |
+      |         | git checkout main                                                                        |
+      | main    | git rebase origin/main                                                                   |
+      |         | git checkout feature                                                                     |
+      | feature | git merge --no-edit origin/feature                                                       |
+      |         | git merge --no-edit main                                                                 |
+      |         | git checkout main                                                                        |
+      | main    | git merge --squash feature                                                               |
+      |         | git commit                                                                               |
+      |         | git commit --amend --no-edit --trailer "Co-authored-by: coworker <coworker@example.com>" |
+      |         | git push                                                                                 |
+      |         | git push origin :feature                                                                 |
+      |         | git branch -D feature                                                                    |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      | AUTHOR                   |
+      | main   | local, origin | feature done | user <email@example.com> |
+    And the last commit on the "main" branch has the trailers:
+      """
+      Co-authored-by: coworker <coworker@example.com>
+      """
+
+  Scenario: undo
+    Given I ran "git-town ship -m 'feature done' --squash-author-mode=current-user"
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH  | COMMAND                                          |
+      | main    | git branch feature {{ sha 'coworker commit 2' }} |
+      |         | git push -u origin feature                       |
+      |         | git revert {{ sha 'feature done' }}              |
+      |         | git push                                         |
+      |         | git checkout feature                             |
+      | feature | git checkout main                                |
+      | main    | git checkout feature                             |
+    And the current branch is now "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE               |
+      | main    | local, origin | feature done          |
+      |         |               | Revert "feature done" |
+      | feature | local, origin | coworker commit 1     |
+      |         |               | my commit             |
+      |         |               | coworker commit 2     |
+    And the initial branches and hierarchy exist
+
+  Scenario: unknown squash author mode
+    When I run "git-town ship -m 'feature done' --squash-author-mode=zonk"
+    Then it runs no commands
+    And it prints the error:
+      """
+      unknown squash author mode: "zonk"
+      """
+    And the current branch is still "feature"
+    And the initial branches and hierarchy exist
@@ -0,0 +1,156 @@
+Feature: handle conflicts while shipping several branches
+
+  Background:
+    Given the feature branches "alpha", "beta", and "gamma"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME        | FILE CONTENT  |
+      | alpha  | local, origin | alpha commit | conflicting_file | alpha content |
+      | beta   | local, origin | beta commit  | conflicting_file | beta content  |
+      | gamma  | local, origin | gamma commit | gamma_file       | gamma content |
+    And the current branch is "main"
+    And I run "git-town ship alpha beta gamma" and enter "alpha done" for the commit message
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | main   | git fetch --prune --tags         |
+      |        | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash alpha         |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :alpha           |
+      |        | git branch -D alpha              |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit main         |
+    And it prints the error:
+      """
+      To abort, run "git-town abort".
+      To continue after having resolved conflicts, run "git-town continue".
+      To continue by skipping the current branch, run "git-town skip".
+      """
+    And the current branch is now "beta"
+    And a merge is now in progress
+
+  Scenario: skip the conflicting branch
+    When I run "git-town skip" and enter "gamma done" for the commit message
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | beta   | git merge --abort                |
+      |        | git checkout gamma               |
+      | gamma  | git merge --no-edit origin/gamma |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash gamma         |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :gamma           |
+      |        | git branch -D gamma              |
+    And the current branch is now "main"
+    And no merge is in progress
+    And the branches are now
+      | REPOSITORY    | BRANCHES   |
+      | local, origin | main, beta |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE     |
+      | main   | local, origin | alpha done  |
+      |        |               | gamma done  |
+      | beta   | local, origin | beta commit |
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | beta   | main   |
+
+  Scenario: skip and undo
+    Given I ran "git-town skip" and entered "gamma done" for the commit message
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                     |
+      | main   | git branch gamma {{ sha 'Merge branch 'main' into gamma' }} |
+      |        | git push -u origin gamma                                    |
+      |        | git revert {{ sha 'gamma done' }}                           |
+      |        | git push                                                    |
+      |        | git checkout gamma                                          |
+      | gamma  | git reset --hard {{ sha 'gamma commit' }}                   |
+      |        | git checkout beta                                           |
+      | beta   | git checkout main                                           |
+      | main   | git branch alpha {{ sha 'alpha commit' }}                   |
+      |        | git push -u origin alpha                                    |
+      |        | git revert {{ sha 'alpha done' }}                           |
+      |        | git push                                                    |
+      |        | git checkout alpha                                          |
+      | alpha  | git checkout main                                           |
+    And the current branch is now "main"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                        |
+      | main   | local, origin | alpha done                     |
+      |        |               | gamma done                     |
+      |        |               | Revert "gamma done"            |
+      |        |               | Revert "alpha done"            |
+      | alpha  | local, origin | alpha commit                   |
+      | beta   | local, origin | beta commit                    |
+      | gamma  | local, origin | gamma commit                   |
+      |        | origin        | alpha done                     |
+      |        |               | Merge branch 'main' into gamma |
+    And the initial branches and hierarchy exist
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH | COMMAND                                   |
+      | beta   | git merge --abort                         |
+      |        | git checkout main                         |
+      | main   | git branch alpha {{ sha 'alpha commit' }} |
+      |        | git push -u origin alpha                  |
+      |        | git revert {{ sha 'alpha done' }}         |
+      |        | git push                                  |
+      |        | git checkout alpha                        |
+      | alpha  | git checkout main                         |
+    And the current branch is now "main"
+    And no merge is in progress
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE             |
+      | main   | local, origin | alpha done          |
+      |        |               | Revert "alpha done" |
+      | alpha  | local, origin | alpha commit        |
+      | beta   | local, origin | beta commit         |
+      | gamma  | local, origin | gamma commit        |
+    And the initial branches and hierarchy exist
+
+  Scenario: resolve and continue
+    When I resolve the conflict in "conflicting_file"
+    And I run "git-town continue" and enter these commit messages:
+      | MESSAGE    |
+      | beta done  |
+      | gamma done |
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | beta   | git commit --no-edit             |
+      |        | git checkout main                |
+      | main   | git merge --squash beta          |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :beta            |
+      |        | git branch -D beta               |
+      |        | git checkout gamma               |
+      | gamma  | git merge --no-edit origin/gamma |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash gamma         |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :gamma           |
+      |        | git branch -D gamma              |
+    And the current branch is now "main"
+    And the branches are now
+      | REPOSITORY    | BRANCHES |
+      | local, origin | main     |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE    |
+      | main   | local, origin | alpha done |
+      |        |               | beta done  |
+      |        |               | gamma done |
+    And no branch hierarchy exists now
@@ -0,0 +1,85 @@
+Feature: ship several independent branches
+
+  Background:
+    Given the feature branches "alpha", "beta", and "gamma"
+    And the commits
+      | BRANCH | LOCATION      | MESSAGE      | FILE NAME  |
+      | alpha  | local, origin | alpha commit | alpha_file |
+      | beta   | local, origin | beta commit  | beta_file  |
+      | gamma  | local, origin | gamma commit | gamma_file |
+    And the current branch is "gamma"
+    When I run "git-town ship alpha beta" and enter these commit messages:
+      | MESSAGE    |
+      | alpha done |
+      | beta done  |
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH | COMMAND                          |
+      | gamma  | git fetch --prune --tags         |
+      |        | git checkout main                |
+      | main   | git rebase origin/main           |
+      |        | git checkout alpha               |
+      | alpha  | git merge --no-edit origin/alpha |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash alpha         |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :alpha           |
+      |        | git branch -D alpha              |
+      |        | git checkout beta                |
+      | beta   | git merge --no-edit origin/beta  |
+      |        | git merge --no-edit main         |
+      |        | git checkout main                |
+      | main   | git merge --squash beta          |
+      |        | git commit                       |
+      |        | git push                         |
+      |        | git push origin :beta            |
+      |        | git branch -D beta               |
+      |        | git checkout gamma               |
+    And the current branch is now "gamma"
+    And the branches are now
+      | REPOSITORY    | BRANCHES    |
+      | local, origin | main, gamma |
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE      |
+      | main   | local, origin | alpha done   |
+      |        |               | beta done    |
+      | gamma  | local, origin | gamma commit |
+    And this branch hierarchy exists now
+      | BRANCH | PARENT |
+      | gamma  | main   |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND                                                   |
+      | gamma  | git checkout main                                         |
+      | main   | git branch beta {{ sha 'Merge branch 'main' into beta' }} |
+      |        | git push -u origin beta                                   |
+      |        | git revert {{ sha 'beta done' }}                          |
+      |        | git push                                                  |
+      |        | git checkout beta                                         |
+      | beta   | git reset --hard {{ sha 'beta commit' }}                  |
+      |        | git checkout main                                         |
+      | main   | git branch alpha {{ sha 'alpha commit' }}                 |
+      |        | git push -u origin alpha                                  |
+      |        | git revert {{ sha 'alpha done' }}                         |
+      |        | git push                                                  |
+      |        | git checkout alpha                                        |
+      | alpha  | git checkout main                                         |
+      | main   | git checkout gamma                                        |
+    And the current branch is now "gamma"
+    And now these commits exist
+      | BRANCH | LOCATION      | MESSAGE                       |
+      | main   | local, origin | alpha done                    |
+      |        |               | beta done                     |
+      |        |               | Revert "beta done"            |
+      |        |               | Revert "alpha done"           |
+      | alpha  | local, origin | alpha commit                  |
+      | beta   | local, origin | beta commit                   |
+      |        | origin        | alpha done                    |
+      |        |               | Merge branch 'main' into beta |
+      | gamma  | local, origin | gamma commit                  |
+    And the initial branches and hierarchy exist
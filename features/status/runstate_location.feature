@@ -0,0 +1,27 @@
+Feature: store the run state in the user's configuration directory
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And I ran "git config git-town.runstate-location config-dir"
+    And I ran "git-town sync"
+
+  Scenario: read-only checkout
+    When I run "git-town status"
+    Then it prints something like:
+      """
+      The last Git Town command \(sync\) hit a problem .*ms ago.
+      """
+    And the Git directory does not contain the run state
+
+  Scenario: migrate to the Git directory
+    Given I ran "git config --unset git-town.runstate-location"
+    When I run "git-town status"
+    Then it prints something like:
+      """
+      The last Git Town command \(sync\) hit a problem .*ms ago.
+      """
+    And the Git directory contains the run state
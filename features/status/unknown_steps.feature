@@ -0,0 +1,53 @@
+Feature: run state that contains steps this version of Git Town cannot run
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And Git Town has stored this run state:
+      """
+      {
+        "AbortStepList": [
+          { "type": "*CheckoutBranchStep", "data": { "BranchName": "main" } }
+        ],
+        "Command": "sync",
+        "RunStepList": [
+          { "type": "*ZonkStep", "data": {} },
+          { "type": "*CheckoutBranchStep", "data": { "BranchName": "feature" } }
+        ],
+        "UndoStepList": [
+          { "type": "*ZonkStep", "data": {} }
+        ],
+        "UnfinishedDetails": {
+          "CanSkip": false,
+          "EndBranch": "feature",
+          "EndTime": "2023-01-01T00:00:00Z"
+        }
+      }
+      """
+
+  Scenario: status
+    When I run "git-town status"
+    Then it prints something like:
+      """
+      The last Git Town command \(sync\) hit a problem .* ago.
+      You can run "git town abort" to abort it.
+      You can run "git town continue" to finish it.
+      This version of Git Town cannot run these stored steps: \*ZonkStep, \*ZonkStep
+      The command still had to run these steps: \*ZonkStep, \*CheckoutStep
+      Aborting or undoing leaves out the stored steps that this version of Git Town cannot run.
+      """
+
+  Scenario: abort
+    When I run "git-town abort"
+    Then it runs the commands
+      | BRANCH  | COMMAND           |
+      | feature | git checkout main |
+    And the current branch is now "main"
+    And the Git directory does not contain the run state
+
+  Scenario: continue
+    When I run "git-town continue"
+    Then it runs no commands
+    And it prints the error:
+      """
+      this version of Git Town cannot run the step "*ZonkStep" from the stored run state
+      """
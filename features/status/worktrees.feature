@@ -0,0 +1,26 @@
+Feature: each worktree has its own run state
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And a feature branch "other"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And I ran "git worktree add ../worktree other"
+    And I ran "git-town sync"
+
+  Scenario: worktree that ran the command
+    When I run "git-town status"
+    Then it prints something like:
+      """
+      The last Git Town command \(sync\) hit a problem .*ms ago.
+      """
+    And the Git directory contains the run state
+
+  Scenario: other worktree
+    When I run "git-town status" in the "../worktree" folder
+    Then it prints:
+      """
+      No status file found for this repository.
+      """
@@ -0,0 +1,33 @@
+Feature: handle an unfinished sync non-interactively
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local    | conflicting main commit    | conflicting_file | main content    |
+      | feature | local    | conflicting feature commit | conflicting_file | feature content |
+    And I ran "git-town sync"
+
+  Scenario: no response provided
+    When I run "git-town sync --non-interactive"
+    Then it runs no commands
+    And it prints the error:
+      """
+      missing input: please provide how to proceed via "--on-unfinished"
+      """
+    And the current branch is still "feature"
+    And a merge is now in progress
+
+  Scenario: abort
+    When I run "git-town sync --non-interactive --on-unfinished=abort"
+    Then it runs the commands
+      | BRANCH  | COMMAND              |
+      | feature | git merge --abort    |
+      |         | git checkout main    |
+      | main    | git checkout feature |
+    And the current branch is still "feature"
+    And no merge is in progress
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE                    | FILE NAME        | FILE CONTENT    |
+      | main    | local, origin | conflicting main commit    | conflicting_file | main content    |
+      | feature | local         | conflicting feature commit | conflicting_file | feature content |
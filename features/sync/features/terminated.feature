@@ -0,0 +1,58 @@
+@skipWindows
+Feature: Git Town gets terminated while syncing
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH  | LOCATION | MESSAGE        |
+      | main    | local    | main commit    |
+      | feature | local    | feature commit |
+    And Git Town gets terminated during the next push
+    When I run "git-town sync"
+
+  Scenario: result
+    Then it runs the commands
+      | BRANCH  | COMMAND                  |
+      | feature | git fetch --prune --tags |
+      |         | git checkout main        |
+      | main    | git rebase origin/main   |
+      |         | git push                 |
+    And it prints the error:
+      """
+      interrupted the "sync" command
+      """
+    And the current branch is now "main"
+
+  Scenario: status
+    When I run "git-town status"
+    Then it prints something like:
+      """
+      The last Git Town command \(sync\) got interrupted .* ago.
+      You can run "git town abort" to abort it.
+      You can run "git town continue" to finish it.
+      You can run "git town undo" to undo what it did so far.
+      """
+
+  Scenario: continue
+    When I run "git-town continue"
+    Then it runs the commands
+      | BRANCH  | COMMAND                            |
+      | main    | git checkout feature               |
+      | feature | git merge --no-edit origin/feature |
+      |         | git merge --no-edit main           |
+      |         | git push                           |
+    And the current branch is now "feature"
+    And now these commits exist
+      | BRANCH  | LOCATION      | MESSAGE                          |
+      | main    | local, origin | main commit                      |
+      | feature | local, origin | feature commit                   |
+      |         |               | main commit                      |
+      |         |               | Merge branch 'main' into feature |
+
+  Scenario: undo
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND              |
+      | main   | git checkout feature |
+    And the current branch is now "feature"
+    And the initial branches and hierarchy exist
@@ -0,0 +1,27 @@
+Feature: refuse to undo commands whose branches changed afterwards
+
+  Background:
+    Given the current branch is a feature branch "feature"
+    And the commits
+      | BRANCH | LOCATION | MESSAGE     |
+      | main   | origin   | main commit |
+    And I ran "git-town sync"
+    And I add commit "manual commit" to the "feature" branch
+
+  Scenario: undo the last command
+    When I run "git-town undo"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot undo "git town sync" because branch "feature" has changed since then, undoing would reset these changes
+      """
+    And the current branch is still "feature"
+
+  Scenario: undo via the journal
+    When I run "git-town undo 1"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot undo "git town sync" because branch "feature" has changed since then, undoing would reset these changes
+      """
+    And the current branch is still "feature"
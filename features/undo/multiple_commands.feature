@@ -0,0 +1,53 @@
+Feature: undo several commands
+
+  Background:
+    Given the current branch is "main"
+    And I ran "git-town hack alpha"
+    And I ran "git-town hack beta"
+
+  Scenario: list the commands that can be undone
+    When I run "git-town undo --list"
+    Then it prints something like:
+      """
+      1  \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}  git town hack beta
+      2  \d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}  git town hack alpha
+      """
+
+  Scenario: undo the last two commands
+    When I run "git-town undo 2"
+    Then it runs the commands
+      | BRANCH | COMMAND             |
+      | beta   | git checkout main   |
+      | main   | git branch -D beta  |
+      |        | git checkout alpha  |
+      | alpha  | git checkout main   |
+      | main   | git branch -D alpha |
+    And the current branch is now "main"
+    And no branch hierarchy exists now
+
+  Scenario: undo the last command and then the one before it
+    Given I ran "git-town undo"
+    When I run "git-town undo"
+    Then it runs the commands
+      | BRANCH | COMMAND             |
+      | alpha  | git checkout main   |
+      | main   | git branch -D alpha |
+    And the current branch is now "main"
+    And no branch hierarchy exists now
+
+  Scenario: undo more commands than Git Town remembers
+    When I run "git-town undo 3"
+    Then it runs no commands
+    And it prints the error:
+      """
+      cannot undo 3 commands because Git Town remembers only 2
+      """
+    And the current branch is still "beta"
+
+  Scenario: invalid number of commands
+    When I run "git-town undo zero"
+    Then it runs no commands
+    And it prints the error:
+      """
+      invalid number of commands to undo: "zero"
+      """
@@ -2,9 +2,9 @@
 package browser
 
 import (
-	"fmt"
 	"runtime"
 
+	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/run"
 )
 
@@ -42,11 +42,11 @@ func OpenBrowserCommand() string {
 func Open(url string, shell run.Shell) {
 	command := OpenBrowserCommand()
 	if command == "" {
-		fmt.Println("Please open in a browser: " + url)
+		cli.Println("Please open in a browser: " + url)
 		return
 	}
 	_, err := shell.Run(command, url)
 	if err != nil {
-		fmt.Println("Please open in a browser: " + url)
+		cli.Println("Please open in a browser: " + url)
 	}
 }
@@ -8,6 +8,10 @@ import (
 
 // PrintDryRunMessage prints the dry-run message.
 func PrintDryRunMessage() {
+	if IsJSONOutput() {
+		emitMessage(dryRunMessage)
+		return
+	}
 	_, err := color.New(color.FgBlue).Print(dryRunMessage)
 	if err != nil {
 		fmt.Print(dryRunMessage)
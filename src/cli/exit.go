@@ -4,6 +4,10 @@ import "os"
 
 // Exit prints the given error message and terminates the application.
 func Exit(err error) {
-	PrintError(err)
+	if IsJSONOutput() {
+		emitFailed(err)
+	} else {
+		PrintError(err)
+	}
 	os.Exit(1)
 }
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Event describes an activity of Git Town in a machine-readable way.
+type Event map[string]interface{}
+
+// StructuredError is implemented by errors that can describe themselves in machine-readable form.
+type StructuredError interface {
+	error
+	// EventFields provides additional fields for the event that reports this error.
+	EventFields() Event
+}
+
+// jsonOutput contains the state of the machine-readable output.
+type jsonOutput struct {
+	command string    // name of the Git Town command that is running
+	writer  io.Writer // receives the events
+}
+
+// activeJSONOutput is the machine-readable output that Git Town currently writes to, nil if it provides human-readable output.
+var activeJSONOutput *jsonOutput //nolint:gochecknoglobals
+
+// EnableJSONOutput makes Git Town describe its activities as a stream of JSON events
+// written to the given writer instead of printing human-readable text.
+func EnableJSONOutput(writer io.Writer, command string, args []string) {
+	activeJSONOutput = &jsonOutput{command: command, writer: writer}
+	EmitEvent("started", Event{"command": command, "args": args})
+}
+
+// IsJSONOutput indicates whether Git Town provides machine-readable output.
+func IsJSONOutput() bool {
+	return activeJSONOutput != nil
+}
+
+// EmitEvent writes the given event of the given type as a single line of JSON to the machine-readable output.
+// Does nothing if Git Town provides human-readable output.
+func EmitEvent(eventType string, event Event) {
+	if activeJSONOutput == nil {
+		return
+	}
+	fields := Event{"event": eventType}
+	for key, value := range event {
+		fields[key] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		data, _ = json.Marshal(Event{"event": "error", "message": fmt.Sprintf("cannot serialize %q event: %v", eventType, err)})
+	}
+	fmt.Fprintln(activeJSONOutput.writer, string(data))
+}
+
+// EmitFinished reports the successful end of the running Git Town command in the machine-readable output.
+func EmitFinished() {
+	if activeJSONOutput != nil {
+		EmitEvent("finished", Event{"command": activeJSONOutput.command})
+	}
+}
+
+// emitFailed reports that the running Git Town command failed with the given error.
+func emitFailed(err error) {
+	event := Event{"command": activeJSONOutput.command, "error": strings.TrimSpace(err.Error())}
+	var structuredErr StructuredError
+	if errors.As(err, &structuredErr) {
+		for key, value := range structuredErr.EventFields() {
+			event[key] = value
+		}
+	}
+	EmitEvent("failed", event)
+}
+
+// emitMessage reports the given human-readable text in the machine-readable output.
+// Empty lines, used in the human-readable output to structure it visually, are omitted.
+func emitMessage(text string) {
+	text = strings.TrimSpace(text)
+	if text != "" {
+		EmitEvent("message", Event{"text": text})
+	}
+}
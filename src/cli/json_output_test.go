@@ -0,0 +1,26 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:paralleltest  // enables the JSON output for the entire package
+func TestJSONOutput(t *testing.T) {
+	output := bytes.Buffer{}
+	cli.EnableJSONOutput(&output, "sync", []string{"--all"})
+	assert.True(t, cli.IsJSONOutput())
+	cli.Printf("\nsyncing %q\n", "feature")
+	cli.Println()
+	cli.EmitEvent("step", cli.Event{"step": "checkout"})
+	cli.EmitFinished()
+	want := `{"args":["--all"],"command":"sync","event":"started"}
+{"event":"message","text":"syncing \"feature\""}
+{"event":"step","step":"checkout"}
+{"command":"sync","event":"finished"}
+`
+	assert.Equal(t, want, output.String())
+}
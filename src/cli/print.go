@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 )
@@ -17,6 +18,10 @@ func BoolSetting(value bool) string {
 // Printf prints the given text using fmt.Printf
 // in a way where colors work on Windows.
 func Printf(format string, a ...interface{}) {
+	if IsJSONOutput() {
+		emitMessage(fmt.Sprintf(format, a...))
+		return
+	}
 	_, err := fmt.Fprintf(color.Output, format, a...)
 	if err != nil {
 		fmt.Printf(format, a...)
@@ -26,6 +31,10 @@ func Printf(format string, a ...interface{}) {
 // Println prints the given text using fmt.Println
 // in a way where colors work on Windows.
 func Println(a ...interface{}) {
+	if IsJSONOutput() {
+		emitMessage(fmt.Sprintln(a...))
+		return
+	}
 	_, err := fmt.Fprintln(color.Output, a...)
 	if err != nil {
 		fmt.Println(a...)
@@ -35,6 +44,10 @@ func Println(a ...interface{}) {
 // PrintlnColor prints using the given color function.
 // If that doesn't work, it falls back to printing without color.
 func PrintlnColor(color *color.Color, messages ...interface{}) {
+	if IsJSONOutput() {
+		emitMessage(fmt.Sprintln(messages...))
+		return
+	}
 	_, err := color.Println(messages...)
 	if err != nil {
 		fmt.Println(messages...)
@@ -47,6 +60,10 @@ func PrintEntry(label, value string) {
 
 // PrintError prints the given error message to the console.
 func PrintError(err error) {
+	if IsJSONOutput() {
+		EmitEvent("error", Event{"message": strings.TrimSpace(err.Error())})
+		return
+	}
 	PrintlnColor(color.New(color.Bold).Add(color.FgRed), "\nError:", err.Error(), "\n")
 }
 
@@ -61,11 +78,15 @@ func PrintHeader(text string) {
 func PrintLabelAndValue(label, value string) {
 	PrintHeader(label)
 	Println(Indent(value))
-	fmt.Println()
+	Println()
 }
 
 // PrintConnectorAction logs activities from a code hosting connector on the CLI.
 func PrintConnectorAction(template string, messages ...interface{}) {
+	if IsJSONOutput() {
+		EmitEvent("hosting", Event{"message": strings.TrimSpace(fmt.Sprintf(template, messages...))})
+		return
+	}
 	fmt.Println()
 	_, err := color.New(color.Bold).Printf(template, messages...)
 	if err != nil {
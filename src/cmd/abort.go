@@ -15,19 +15,9 @@ func abortCmd(repo *git.ProdRepo) *cobra.Command {
 		Use:   "abort",
 		Short: "Aborts the last run git-town command",
 		Run: func(cmd *cobra.Command, args []string) {
-			runState, err := runstate.Load(repo)
-			if err != nil {
-				cli.Exit(fmt.Errorf("cannot load previous run state: %w", err))
-			}
-			if runState == nil || !runState.IsUnfinished() {
-				cli.Exit(fmt.Errorf("nothing to abort"))
-			}
-			abortRunState := runState.CreateAbortRunState()
-			connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
-			if err != nil {
-				cli.Exit(err)
-			}
-			err = runstate.Execute(&abortRunState, repo, connector)
+			err := withLock(repo, func() error {
+				return abortLastCommand(repo)
+			})
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -42,3 +32,20 @@ func abortCmd(repo *git.ProdRepo) *cobra.Command {
 		GroupID: "errors",
 	}
 }
+
+// abortLastCommand aborts the unfinished Git Town command.
+func abortLastCommand(repo *git.ProdRepo) error {
+	runState, err := runstate.Load(repo)
+	if err != nil {
+		return fmt.Errorf("cannot load previous run state: %w", err)
+	}
+	if runState == nil || !runState.IsUnfinished() {
+		return fmt.Errorf("nothing to abort")
+	}
+	abortRunState := runState.CreateAbortRunState()
+	connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+	if err != nil {
+		return err
+	}
+	return runstate.Execute(&abortRunState, repo, connector)
+}
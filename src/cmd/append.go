@@ -10,7 +10,8 @@ import (
 )
 
 func appendCmd(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	dryRunFlag := false
+	appendCmd := cobra.Command{
 		Use:   "append <branch>",
 		Short: "Creates a new feature branch as a child of the current branch",
 		Long: `Creates a new feature branch as a direct child of the current branch.
@@ -43,10 +44,15 @@ See "sync" for information regarding upstream remotes.`,
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 		GroupID: "lineage",
 	}
+	addDryRunFlag(&appendCmd, &dryRunFlag)
+	return &appendCmd
 }
 
 type appendConfig struct {
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/stringslice"
+	"github.com/spf13/cobra"
+)
+
+func branchesCommand(repo *git.ProdRepo) *cobra.Command {
+	return &cobra.Command{
+		Use:   "branches",
+		Short: "Displays the branch hierarchy together with the status of each branch",
+		Long: `Displays the branch hierarchy together with the status of each branch
+
+For each branch, shows how many commits it is ahead of and behind its parent branch and its tracking branch,
+and whether the branch exists only locally or its tracking branch has been deleted.
+
+If API access to your code hosting service is configured and Git Town is online,
+also shows the proposal for each branch and the status of its CI jobs.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			config, err := determineBranchStatusConfig(repo)
+			if err != nil {
+				cli.Exit(err)
+			}
+			lines := []string{}
+			for _, root := range branchOverviewRoots(repo) {
+				lines, err = addBranchOverviewLines(lines, root, 0, config, repo)
+				if err != nil {
+					cli.Exit(err)
+				}
+			}
+			cli.Println(strings.Join(lines, "\n"))
+		},
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := ValidateIsRepository(repo); err != nil {
+				return err
+			}
+			return validateIsConfigured(repo)
+		},
+		GroupID: "lineage",
+	}
+}
+
+// branchStatusConfig contains the information needed to determine the status of branches.
+type branchStatusConfig struct {
+	branchesWithDeletedTrackingBranch []string
+	connector                         hosting.Connector // nil if the hosting service isn't available
+	hasOrigin                         bool
+	localBranches                     []string
+}
+
+func determineBranchStatusConfig(repo *git.ProdRepo) (*branchStatusConfig, error) {
+	hasOrigin, err := repo.Silent.HasOrigin()
+	if err != nil {
+		return nil, err
+	}
+	isOffline, err := repo.Config.IsOffline()
+	if err != nil {
+		return nil, err
+	}
+	localBranches, err := repo.Silent.LocalBranches()
+	if err != nil {
+		return nil, err
+	}
+	branchesWithDeletedTrackingBranch, err := repo.Silent.LocalBranchesWithDeletedTrackingBranches()
+	if err != nil {
+		return nil, err
+	}
+	var connector hosting.Connector
+	if hasOrigin && !isOffline {
+		connector, err = hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &branchStatusConfig{
+		branchesWithDeletedTrackingBranch: branchesWithDeletedTrackingBranch,
+		connector:                         connector,
+		hasOrigin:                         hasOrigin,
+		localBranches:                     localBranches,
+	}, nil
+}
+
+// branchOverviewRoots provides the branches at the top of the branch hierarchy,
+// starting with the main branch.
+func branchOverviewRoots(repo *git.ProdRepo) []string {
+	roots := repo.Config.BranchAncestryRoots()
+	mainBranch := repo.Config.MainBranch()
+	if !stringslice.Contains(roots, mainBranch) {
+		roots = append(roots, mainBranch)
+	}
+	return stringslice.Hoist(roots, mainBranch)
+}
+
+// addBranchOverviewLines adds the lines describing the given branch and all its child branches to the given lines.
+func addBranchOverviewLines(lines []string, branch string, indent int, config *branchStatusConfig, repo *git.ProdRepo) ([]string, error) {
+	annotations, err := branchAnnotations(branch, config, repo)
+	if err != nil {
+		return lines, err
+	}
+	lines = append(lines, strings.Repeat("  ", indent)+annotatedBranchName(branch, annotations))
+	for _, child := range repo.Config.ChildBranches(branch) {
+		lines, err = addBranchOverviewLines(lines, child, indent+1, config, repo)
+		if err != nil {
+			return lines, err
+		}
+	}
+	return lines, nil
+}
+
+// annotatedBranchName provides the given branch name followed by the given annotations.
+func annotatedBranchName(branch string, annotations []string) string {
+	if len(annotations) == 0 {
+		return branch
+	}
+	return branch + "  " + strings.Join(annotations, " | ")
+}
+
+// branchAnnotations describes how the given branch relates to its parent branch, its tracking branch, and its proposal.
+func branchAnnotations(branch string, config *branchStatusConfig, repo *git.ProdRepo) ([]string, error) {
+	if !stringslice.Contains(config.localBranches, branch) {
+		return []string{"no local branch"}, nil
+	}
+	result := []string{}
+	parent := repo.Config.ParentBranch(branch)
+	if stringslice.Contains(config.localBranches, parent) {
+		ahead, behind, err := repo.Silent.AheadBehind(branch, parent)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, describeAheadBehind(ahead, behind, parent))
+	}
+	if config.hasOrigin {
+		hasTrackingBranch, err := repo.Silent.HasTrackingBranch(branch)
+		if err != nil {
+			return result, err
+		}
+		switch {
+		case stringslice.Contains(config.branchesWithDeletedTrackingBranch, branch):
+			result = append(result, "tracking branch deleted")
+		case !hasTrackingBranch:
+			result = append(result, "local only")
+		default:
+			trackingBranch := repo.Silent.TrackingBranch(branch)
+			ahead, behind, err := repo.Silent.AheadBehind(branch, trackingBranch)
+			if err != nil {
+				return result, err
+			}
+			result = append(result, describeAheadBehind(ahead, behind, trackingBranch))
+		}
+	}
+	if config.connector != nil && parent != "" {
+		proposal, err := config.connector.FindProposal(branch, parent)
+		if err != nil {
+			return result, err
+		}
+		if proposal != nil {
+			checks, err := config.connector.ProposalChecks(proposal.Number)
+			if err != nil {
+				return result, err
+			}
+			result = append(result, describeProposal(*proposal, *checks))
+		}
+	}
+	return result, nil
+}
+
+// describeAheadBehind describes the given numbers of commits that a branch is ahead of and behind the given other branch.
+func describeAheadBehind(ahead, behind int, otherBranch string) string {
+	switch {
+	case ahead == 0 && behind == 0:
+		return "in sync with " + otherBranch
+	case behind == 0:
+		return fmt.Sprintf("%d ahead of %s", ahead, otherBranch)
+	case ahead == 0:
+		return fmt.Sprintf("%d behind %s", behind, otherBranch)
+	default:
+		return fmt.Sprintf("%d ahead and %d behind %s", ahead, behind, otherBranch)
+	}
+}
+
+// describeProposal describes the state of the given proposal, its CI jobs, and its reviews.
+func describeProposal(proposal hosting.Proposal, checks hosting.ProposalChecks) string {
+	state := "open"
+	if proposal.Draft {
+		state = "draft"
+	}
+	result := fmt.Sprintf("proposal #%d %s", proposal.Number, state)
+	switch checks.CIStatus {
+	case hosting.CIStatusSuccess:
+		result += ", CI passed"
+	case hosting.CIStatusPending:
+		result += ", CI pending"
+	case hosting.CIStatusFailure:
+		result += ", CI failed"
+	case hosting.CIStatusNone:
+	}
+	if checks.RequiredApprovals > 0 {
+		result += fmt.Sprintf(", %d of %d approvals", checks.Approvals, checks.RequiredApprovals)
+	}
+	return result
+}
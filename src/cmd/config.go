@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/git-town/git-town/v7/src/cli"
@@ -21,32 +20,40 @@ func configCmd(repo *git.ProdRepo) *cobra.Command {
 			isOffline := ec.Bool(repo.Config.IsOffline())
 			deleteOrigin := ec.Bool(repo.Config.ShouldShipDeleteOriginBranch())
 			pullBranchStrategy := ec.PullBranchStrategy(repo.Config.PullBranchStrategy())
+			shipStrategy := ec.ShipStrategy(repo.Config.ShipStrategy())
+			squashAuthorMode := ec.SquashAuthorMode(repo.Config.SquashAuthorMode())
 			shouldSyncUpstream := ec.Bool(repo.Config.ShouldSyncUpstream())
 			syncStrategy := ec.SyncStrategy(repo.Config.SyncStrategy())
 			hostingService := ec.HostingService(repo.Config.HostingService())
 			if ec.Err != nil {
 				cli.Exit(ec.Err)
 			}
-			fmt.Println()
+			cli.Println()
 			cli.PrintHeader("Branches")
 			cli.PrintEntry("main branch", cli.StringSetting(repo.Config.MainBranch()))
 			cli.PrintEntry("perennial branches", cli.StringSetting(strings.Join(repo.Config.PerennialBranches(), ", ")))
-			fmt.Println()
+			cli.Println()
 			cli.PrintHeader("Configuration")
 			cli.PrintEntry("offline", cli.BoolSetting(isOffline))
 			cli.PrintEntry("pull branch strategy", string(pullBranchStrategy))
 			cli.PrintEntry("run pre-push hook", cli.BoolSetting(pushHook))
 			cli.PrintEntry("push new branches", cli.BoolSetting(pushNewBranches))
 			cli.PrintEntry("ship removes the remote branch", cli.BoolSetting(deleteOrigin))
+			cli.PrintEntry("ship message template", cli.StringSetting(strings.ReplaceAll(repo.Config.ShipMessageTemplate(), "\n", `\n`)))
+			cli.PrintEntry("ship strategy", string(shipStrategy))
+			cli.PrintEntry("squash author mode", string(squashAuthorMode))
 			cli.PrintEntry("sync strategy", string(syncStrategy))
 			cli.PrintEntry("sync with upstream", cli.BoolSetting(shouldSyncUpstream))
-			fmt.Println()
+			cli.Println()
 			cli.PrintHeader("Hosting")
 			cli.PrintEntry("hosting service override", cli.StringSetting(string(hostingService)))
+			cli.PrintEntry("Azure DevOps token", cli.StringSetting(repo.Config.AzureToken()))
+			cli.PrintEntry("Bitbucket token", cli.StringSetting(repo.Config.BitbucketToken()))
+			cli.PrintEntry("GitHub API URL", cli.StringSetting(repo.Config.GitHubAPIURL()))
 			cli.PrintEntry("GitHub token", cli.StringSetting(repo.Config.GitHubToken()))
 			cli.PrintEntry("GitLab token", cli.StringSetting(repo.Config.GitLabToken()))
 			cli.PrintEntry("Gitea token", cli.StringSetting(repo.Config.GiteaToken()))
-			fmt.Println()
+			cli.Println()
 			if repo.Config.MainBranch() != "" {
 				cli.PrintLabelAndValue("Branch Ancestry", cli.PrintableBranchAncestry(&repo.Config))
 			}
@@ -66,5 +73,6 @@ func configCmd(repo *git.ProdRepo) *cobra.Command {
 	configCmd.AddCommand(resetConfigCommand(repo))
 	configCmd.AddCommand(setupConfigCommand(repo))
 	configCmd.AddCommand(syncStrategyCommand(repo))
+	configCmd.AddCommand(tokensConfigCmd(repo))
 	return configCmd
 }
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/spf13/cobra"
+)
+
+func tokensConfigCmd(repo *git.ProdRepo) *cobra.Command {
+	return &cobra.Command{
+		Use:   "tokens",
+		Short: "Displays the API tokens for code hosting services and where they come from",
+		Long: fmt.Sprintf(`Displays the API tokens for code hosting services and where they come from
+
+For each code hosting service, Git Town uses the first API token it finds in:
+- the setting "%s" for the hostname of the origin remote
+- the setting "git-town.<service>-token"
+- the GITHUB_TOKEN or GITLAB_TOKEN environment variable for GitHub or GitLab
+- the Git credential helpers for the hostname of the origin remote, for GitHub, GitLab, and Gitea
+
+This command displays the tokens masked.`, config.HostTokenKey(config.HostingServiceGitHub, "<hostname>")),
+		Run: func(cmd *cobra.Command, args []string) {
+			originService, originHostname, err := hosting.OriginHost(&repo.Config, &repo.Silent)
+			if err != nil {
+				cli.Exit(err)
+			}
+			cli.Println()
+			cli.PrintHeader("API tokens")
+			for _, entry := range []struct {
+				label   string
+				service config.HostingService
+			}{
+				{"Azure DevOps token", config.HostingServiceAzure},
+				{"Bitbucket token", config.HostingServiceBitbucket},
+				{"GitHub token", config.HostingServiceGitHub},
+				{"GitLab token", config.HostingServiceGitLab},
+				{"Gitea token", config.HostingServiceGitea},
+			} {
+				// the sources for a specific hostname apply only to the service at the origin remote
+				hostname := ""
+				if entry.service == originService || (entry.service == config.HostingServiceBitbucket && originService == config.HostingServiceBitbucketServer) {
+					hostname = originHostname
+				}
+				cli.PrintEntry(entry.label, describeToken(repo.Config.APIToken(entry.service, hostname)))
+			}
+			cli.Println()
+		},
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return ValidateIsRepository(repo)
+		},
+	}
+}
+
+// describeToken describes the given token and where it comes from without revealing it.
+func describeToken(token config.Token) string {
+	if token.Value == "" {
+		return cli.StringSetting("")
+	}
+	return fmt.Sprintf("%s (from %s)", token.Masked(), token.Source)
+}
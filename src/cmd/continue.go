@@ -15,25 +15,9 @@ func continueCmd(repo *git.ProdRepo) *cobra.Command {
 		Use:   "continue",
 		Short: "Restarts the last run git-town command after having resolved conflicts",
 		Run: func(cmd *cobra.Command, args []string) {
-			runState, err := runstate.Load(repo)
-			if err != nil {
-				cli.Exit(fmt.Errorf("cannot load previous run state: %w", err))
-			}
-			if runState == nil || !runState.IsUnfinished() {
-				cli.Exit(fmt.Errorf("nothing to continue"))
-			}
-			hasConflicts, err := repo.Silent.HasConflicts()
-			if err != nil {
-				cli.Exit(err)
-			}
-			if hasConflicts {
-				cli.Exit(fmt.Errorf("you must resolve the conflicts before continuing"))
-			}
-			connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
-			if err != nil {
-				cli.Exit(err)
-			}
-			err = runstate.Execute(runState, repo, connector)
+			err := withLock(repo, func() error {
+				return continueLastCommand(repo)
+			})
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -48,3 +32,26 @@ func continueCmd(repo *git.ProdRepo) *cobra.Command {
 		GroupID: "errors",
 	}
 }
+
+// continueLastCommand continues the unfinished Git Town command.
+func continueLastCommand(repo *git.ProdRepo) error {
+	runState, err := runstate.Load(repo)
+	if err != nil {
+		return fmt.Errorf("cannot load previous run state: %w", err)
+	}
+	if runState == nil || !runState.IsUnfinished() {
+		return fmt.Errorf("nothing to continue")
+	}
+	hasConflicts, err := repo.Silent.HasConflicts()
+	if err != nil {
+		return err
+	}
+	if hasConflicts {
+		return fmt.Errorf("you must resolve the conflicts before continuing")
+	}
+	connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+	if err != nil {
+		return err
+	}
+	return runstate.Execute(runState, repo, connector)
+}
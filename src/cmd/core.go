@@ -23,6 +23,8 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/git-town/git-town/v7/src/cli"
@@ -30,6 +32,7 @@ import (
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/git-town/git-town/v7/src/runstate"
+	"github.com/git-town/git-town/v7/src/stringslice"
 	"github.com/spf13/cobra"
 )
 
@@ -77,6 +80,7 @@ and it allows you to perform many common Git operations faster and easier.`,
 	rootCmd.AddCommand(abortCmd(repo))
 	rootCmd.AddCommand(aliasCommand(repo))
 	rootCmd.AddCommand(appendCmd(repo))
+	rootCmd.AddCommand(branchesCommand(repo))
 	rootCmd.AddCommand(completionsCmd(&rootCmd))
 	rootCmd.AddCommand(configCmd(repo))
 	rootCmd.AddCommand(continueCmd(repo))
@@ -98,9 +102,94 @@ and it allows you to perform many common Git operations faster and easier.`,
 	rootCmd.AddCommand(versionCmd())
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.PersistentFlags().BoolVar(debugFlag, "debug", false, "Print all Git commands run under the hood")
+	outputFlag := ""
+	rootCmd.PersistentFlags().StringVar(&outputFlag, "output", "text", `Output format: "text" for humans or "json" for a machine-readable stream of events`)
+	answers := dialog.Answers{} //nolint:exhaustruct  // populated by the flags below
+	rootCmd.PersistentFlags().BoolVar(&answers.NonInteractive, "non-interactive", false, "Fail instead of asking questions that have no answer provided via flags")
+	rootCmd.PersistentFlags().StringVar(&answers.Parent, "parent", "", "Parent for branches whose parent is unknown")
+	rootCmd.PersistentFlags().StringVar(&answers.SquashAuthor, "squash-author", "", "Author of squash commits for branches with multiple authors")
+	rootCmd.PersistentFlags().StringVar(&answers.OnUnfinished, "on-unfinished", "", "How to handle an unfinished Git Town command: continue, abort, discard, or skip")
+	waitFlag := false
+	rootCmd.PersistentFlags().BoolVar(&waitFlag, "wait", false, "Wait for other Git Town commands in this repository to finish instead of failing")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		err := enableOutputFormat(outputFlag, cmd, args)
+		if err != nil {
+			return err
+		}
+		runstate.WaitForLock(waitFlag)
+		return useAnswers(answers)
+	}
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		cli.EmitFinished()
+	}
 	return &rootCmd
 }
 
+// enableOutputFormat makes the given command report its activities in the given output format.
+func enableOutputFormat(format string, cmd *cobra.Command, args []string) error {
+	switch format {
+	case "text":
+		return nil
+	case "json":
+		color.NoColor = true
+		// errors are reported as events
+		cmd.Root().SilenceErrors = true
+		cmd.Root().SilenceUsage = true
+		cli.EnableJSONOutput(os.Stdout, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "), args)
+		return nil
+	default:
+		return fmt.Errorf(`unknown output format %q, please use "text" or "json"`, format)
+	}
+}
+
+// nonInteractiveEnvVar is the environment variable that enables the non-interactive mode.
+const nonInteractiveEnvVar = "GIT_TOWN_NONINTERACTIVE"
+
+// useAnswers makes the dialogs use the given answers provided via CLI flags and the environment.
+func useAnswers(answers dialog.Answers) error {
+	if value, isSet := os.LookupEnv(nonInteractiveEnvVar); isSet && value != "" {
+		nonInteractive, err := cli.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for environment variable %s: %q", nonInteractiveEnvVar, value)
+		}
+		answers.NonInteractive = answers.NonInteractive || nonInteractive
+	}
+	if answers.OnUnfinished != "" && !stringslice.Contains(dialog.UnfinishedRunStateResponses(), answers.OnUnfinished) {
+		return fmt.Errorf("unknown value for --on-unfinished: %q, please use one of %s", answers.OnUnfinished, strings.Join(dialog.UnfinishedRunStateResponses(), ", "))
+	}
+	dialog.UseAnswers(answers)
+	return nil
+}
+
+// addDryRunFlag adds the "--dry-run" flag to the given command.
+func addDryRunFlag(cmd *cobra.Command, dryRunFlag *bool) {
+	cmd.Flags().BoolVar(dryRunFlag, "dry-run", false, "Print the commands but don't run them")
+}
+
+// activateDryRun makes the given repo simulate the changes of the running command if the given dry-run flag is set.
+func activateDryRun(dryRunFlag bool, repo *git.ProdRepo) error {
+	if !dryRunFlag {
+		return nil
+	}
+	return repo.ActivateDryRun()
+}
+
+// withLock runs the given function while holding the lock
+// that prevents other Git Town processes from changing the given repo at the same time.
+func withLock(repo *git.ProdRepo, run func() error) error {
+	lock, err := runstate.AcquireLock(repo)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+	return run()
+}
+
+// CanAddTrailers indicates whether the given Git version can add trailers to commits.
+func CanAddTrailers(major, minor int) bool {
+	return major > 2 || (major == 2 && minor >= 32)
+}
+
 // IsAcceptableGitVersion indicates whether the given Git version works for Git Town.
 func IsAcceptableGitVersion(major, minor int) bool {
 	return major > 2 || (major == 2 && minor >= 7)
@@ -127,6 +216,11 @@ func ValidateIsRepository(repo *git.ProdRepo) error {
 //
 //nolint:nonamedreturns  // return value isn't obvious from function name
 func handleUnfinishedState(repo *git.ProdRepo, connector hosting.Connector) (quit bool, err error) {
+	lock, err := runstate.AcquireLock(repo)
+	if err != nil {
+		return false, err
+	}
+	defer lock.Release()
 	runState, err := runstate.Load(repo)
 	if err != nil {
 		return false, fmt.Errorf("cannot load previous run state: %w", err)
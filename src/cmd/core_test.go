@@ -8,6 +8,25 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestCanAddTrailers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		major int
+		minor int
+		want  bool
+	}{
+		{2, 32, true},
+		{2, 39, true},
+		{3, 0, true},
+		{2, 31, false},
+		{1, 40, false},
+	}
+	for _, test := range tests {
+		have := cmd.CanAddTrailers(test.major, test.minor)
+		assert.Equal(t, test.want, have, fmt.Sprintf("%d.%d --> %t", test.major, test.minor, test.want))
+	}
+}
+
 func TestIsAcceptableGitVersion(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -12,6 +12,7 @@ import (
 
 func hackCmd(repo *git.ProdRepo) *cobra.Command {
 	promptForParentFlag := false
+	dryRunFlag := false
 	hackCmd := cobra.Command{
 		Use:   "hack <branch>",
 		Short: "Creates a new feature branch off the main development branch",
@@ -44,11 +45,15 @@ See "sync" for information regarding upstream remotes.`,
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 		GroupID: "basic",
 	}
 	hackCmd.Flags().BoolVarP(&promptForParentFlag, "prompt", "p", false, "Prompt for the parent branch")
+	addDryRunFlag(&hackCmd, &dryRunFlag)
 	return &hackCmd
 }
 
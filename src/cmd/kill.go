@@ -12,7 +12,8 @@ import (
 )
 
 func killCommand(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	dryRunFlag := false
+	killCmd := cobra.Command{
 		Use:   "kill [<branch>]",
 		Short: "Removes an obsolete feature branch",
 		Long: `Removes an obsolete feature branch
@@ -39,9 +40,14 @@ Does not delete perennial branches nor the main branch.`,
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 	}
+	addDryRunFlag(&killCmd, &dryRunFlag)
+	return &killCmd
 }
 
 type killConfig struct {
@@ -154,6 +160,7 @@ func killStepList(config *killConfig, repo *git.ProdRepo) (runstate.StepList, er
 			result.Append(&steps.SetParentStep{Branch: child, ParentBranch: config.targetBranchParent})
 		}
 		result.Append(&steps.DeleteParentBranchStep{Branch: config.targetBranch})
+		result.Append(&steps.DeleteAutoMergeProposalStep{Branch: config.targetBranch})
 	case !config.isOffline:
 		result.Append(&steps.DeleteOriginBranchStep{Branch: config.targetBranch, IsTracking: false, NoPushHook: config.noPushHook})
 	default:
@@ -14,7 +14,9 @@ import (
 )
 
 func newPullRequestCommand(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	flags := newPullRequestFlags{}
+	dryRunFlag := false
+	newPullRequestCmd := cobra.Command{
 		Use:   "new-pull-request",
 		Short: "Creates a new pull request",
 		Long: fmt.Sprintf(`Creates a new pull request
@@ -26,15 +28,37 @@ The form is pre-populated for the current branch
 so that the pull request only shows the changes made
 against the immediate parent branch.
 
-Supported only for repositories hosted on GitHub, GitLab, Gitea and Bitbucket.
+With the "--api" flag, creates the pull request directly
+via the API of your code hosting service and prints its URL.
+This works without a browser, for example over SSH.
+The "--title", "--body", and "--draft" flags provide the details
+of the new pull request and imply "--api".
+The title defaults to the name of the branch.
+Creating pull requests via the API is supported for GitHub, GitLab, and Gitea
+and requires an API token.
+"git town undo" closes pull requests created this way.
+
+With the "--stack" flag, creates or finds a pull request
+for each feature branch in the lineage of the current branch,
+i.e. its ancestors and descendants, each against its parent branch.
+The lineage ends at descendants that have several child branches.
+It also writes a section listing all pull requests of the stack
+into the description of each of them
+and updates this section when running this command again.
+This flag implies "--api".
+
+If the origin remote is a fork of the repository at the "upstream" remote,
+creates pull requests in the upstream repository.
+
+Supported only for repositories hosted on GitHub, GitLab, Gitea, Bitbucket, Bitbucket Server, and Azure DevOps.
 When using self-hosted versions this command needs to be configured with
 "git config %s <driver>"
-where driver is "github", "gitlab", "gitea", or "bitbucket".
+where driver is "github", "gitlab", "gitea", "bitbucket", "bitbucket-server", or "azure".
 When using SSH identities, this command needs to be configured with
 "git config %s <hostname>"
 where hostname matches what is in your ssh config file.`, config.CodeHostingDriverKey, config.CodeHostingOriginHostnameKey),
 		Run: func(cmd *cobra.Command, args []string) {
-			config, err := determineNewPullRequestConfig(repo)
+			config, err := determineNewPullRequestConfig(flags, repo)
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -60,6 +84,9 @@ where hostname matches what is in your ssh config file.`, config.CodeHostingDriv
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			if err := validateIsConfigured(repo); err != nil {
 				return err
 			}
@@ -70,14 +97,35 @@ where hostname matches what is in your ssh config file.`, config.CodeHostingDriv
 		},
 		GroupID: "basic",
 	}
+	newPullRequestCmd.Flags().BoolVar(&flags.api, "api", false, "Create the pull request via the API instead of opening a browser")
+	newPullRequestCmd.Flags().StringVar(&flags.title, "title", "", "Specify the title of the pull request")
+	newPullRequestCmd.Flags().StringVar(&flags.body, "body", "", "Specify the description of the pull request")
+	newPullRequestCmd.Flags().BoolVar(&flags.draft, "draft", false, "Create the pull request as a draft")
+	newPullRequestCmd.Flags().BoolVar(&flags.stack, "stack", false, "Create pull requests for all branches in the lineage of the current branch")
+	addDryRunFlag(&newPullRequestCmd, &dryRunFlag)
+	return &newPullRequestCmd
+}
+
+// newPullRequestFlags contains the CLI flags for creating pull requests via the API.
+type newPullRequestFlags struct {
+	api   bool
+	body  string
+	draft bool
+	stack bool
+	title string
 }
 
 type newPullRequestConfig struct {
 	BranchesToSync []string
+	Draft          bool
 	InitialBranch  string
+	ProposalBody   string
+	ProposalTitle  string
+	StackBranches  []string // feature branches to create proposals for, empty when not creating proposals for a stack
+	UseAPI         bool
 }
 
-func determineNewPullRequestConfig(repo *git.ProdRepo) (*newPullRequestConfig, error) {
+func determineNewPullRequestConfig(flags newPullRequestFlags, repo *git.ProdRepo) (*newPullRequestConfig, error) {
 	hasOrigin, err := repo.Silent.HasOrigin()
 	if err != nil {
 		return nil, err
@@ -97,9 +145,27 @@ func determineNewPullRequestConfig(repo *git.ProdRepo) (*newPullRequestConfig, e
 	if err != nil {
 		return nil, err
 	}
+	branchesToSync := append(repo.Config.AncestorBranches(initialBranch), initialBranch)
+	stackBranches := []string{}
+	if flags.stack {
+		if !repo.Config.IsFeatureBranch(initialBranch) {
+			return nil, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can be part of a stack", initialBranch)
+		}
+		branchesToSync = append(branchesToSync, repo.Config.LinearDescendantBranches(initialBranch)...)
+		for _, branch := range branchesToSync {
+			if repo.Config.IsFeatureBranch(branch) {
+				stackBranches = append(stackBranches, branch)
+			}
+		}
+	}
 	return &newPullRequestConfig{
 		InitialBranch:  initialBranch,
-		BranchesToSync: append(repo.Config.AncestorBranches(initialBranch), initialBranch),
+		BranchesToSync: branchesToSync,
+		Draft:          flags.draft,
+		ProposalBody:   flags.body,
+		ProposalTitle:  flags.title,
+		StackBranches:  stackBranches,
+		UseAPI:         flags.api || flags.title != "" || flags.body != "" || flags.draft || flags.stack,
 	}, nil
 }
 
@@ -108,7 +174,33 @@ func newPullRequestStepList(config *newPullRequestConfig, repo *git.ProdRepo) (r
 	for _, branch := range config.BranchesToSync {
 		updateBranchSteps(&list, branch, true, repo)
 	}
+	// syncing the descendants of the current branch checks them out
+	list.Add(&steps.CheckoutStep{Branch: config.InitialBranch})
 	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: true}, repo)
-	list.Add(&steps.CreateProposalStep{Branch: config.InitialBranch})
+	if len(config.StackBranches) == 0 {
+		list.Add(&steps.CreateProposalStep{
+			Body:   config.ProposalBody,
+			Branch: config.InitialBranch,
+			Draft:  config.Draft,
+			Title:  config.ProposalTitle,
+			UseAPI: config.UseAPI,
+		})
+		return list.Result()
+	}
+	for _, branch := range config.StackBranches {
+		// the given title and body apply only to the proposal for the current branch
+		body, title := "", ""
+		if branch == config.InitialBranch {
+			body, title = config.ProposalBody, config.ProposalTitle
+		}
+		list.Add(&steps.CreateProposalStep{
+			Body:   body,
+			Branch: branch,
+			Draft:  config.Draft,
+			Title:  title,
+			UseAPI: true,
+		})
+	}
+	list.Add(&steps.UpdateProposalStackStep{Branches: config.StackBranches})
 	return list.Result()
 }
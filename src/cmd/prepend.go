@@ -12,7 +12,8 @@ import (
 )
 
 func prependCommand(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	dryRunFlag := false
+	prependCmd := cobra.Command{
 		Use:   "prepend <branch>",
 		Short: "Creates a new feature branch as the parent of the current branch",
 		Long: `Creates a new feature branch as the parent of the current branch
@@ -38,7 +39,7 @@ See "sync" for upstream remote options.
 			runState := runstate.New("prepend", stepList)
 			err = runstate.Execute(runState, repo, nil)
 			if err != nil {
-				fmt.Println(err)
+				cli.Println(err)
 				cli.Exit(err)
 			}
 		},
@@ -47,10 +48,15 @@ See "sync" for upstream remote options.
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 		GroupID: "lineage",
 	}
+	addDryRunFlag(&prependCmd, &dryRunFlag)
+	return &prependCmd
 }
 
 type prependConfig struct {
@@ -9,7 +9,8 @@ import (
 )
 
 func pruneBranchesCommand(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	dryRunFlag := false
+	pruneBranchesCmd := cobra.Command{
 		Use:   "prune-branches",
 		Short: "Deletes local branches whose tracking branch no longer exists",
 		Long: `Deletes local branches whose tracking branch no longer exists
@@ -36,12 +37,17 @@ This usually means the branch was shipped or killed on another machine.`,
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			if err := validateIsConfigured(repo); err != nil {
 				return err
 			}
 			return repo.Config.ValidateIsOnline()
 		},
 	}
+	addDryRunFlag(&pruneBranchesCmd, &dryRunFlag)
+	return &pruneBranchesCmd
 }
 
 type pruneBranchesConfig struct {
@@ -88,6 +94,7 @@ func pruneBranchesStepList(config *pruneBranchesConfig, repo *git.ProdRepo) (run
 				result.Append(&steps.SetParentStep{Branch: child, ParentBranch: parent})
 			}
 			result.Append(&steps.DeleteParentBranchStep{Branch: branchWithDeletedRemote})
+			result.Append(&steps.DeleteAutoMergeProposalStep{Branch: branchWithDeletedRemote})
 		}
 		if repo.Config.IsPerennialBranch(branchWithDeletedRemote) {
 			result.Append(&steps.RemoveFromPerennialBranchesStep{Branch: branchWithDeletedRemote})
@@ -12,6 +12,7 @@ import (
 
 func renameBranchCommand(repo *git.ProdRepo) *cobra.Command {
 	forceFlag := false
+	dryRunFlag := false
 	renameBranchCmd := &cobra.Command{
 		Use:   "rename-branch [<old_branch_name>] <new_branch_name>",
 		Short: "Renames a branch both locally and remotely",
@@ -53,10 +54,14 @@ When run on a perennial branch
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 	}
 	renameBranchCmd.Flags().BoolVar(&forceFlag, "force", false, "Force rename of perennial branch")
+	addDryRunFlag(renameBranchCmd, &dryRunFlag)
 	return renameBranchCmd
 }
 
@@ -158,6 +163,7 @@ func renameBranchStepList(config *renameBranchConfig, repo *git.ProdRepo) (runst
 		result.Append(&steps.AddToPerennialBranchesStep{Branch: config.newBranch})
 	} else {
 		result.Append(&steps.DeleteParentBranchStep{Branch: config.oldBranch})
+		result.Append(&steps.DeleteAutoMergeProposalStep{Branch: config.oldBranch})
 		result.Append(&steps.SetParentStep{Branch: config.newBranch, ParentBranch: repo.Config.ParentBranch(config.oldBranch)})
 	}
 	for _, child := range config.oldBranchChildren {
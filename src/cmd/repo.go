@@ -17,11 +17,13 @@ func repoCommand(repo *git.ProdRepo) *cobra.Command {
 		Short: "Opens the repository homepage",
 		Long: fmt.Sprintf(`Opens the repository homepage
 
-Supported for repositories hosted on GitHub, GitLab, Gitea, and Bitbucket.
+Supported for repositories hosted on GitHub, GitLab, Gitea, Bitbucket, Bitbucket Server, and Azure DevOps.
 Derives the Git provider from the "origin" remote.
+If the origin remote is a fork of the repository at the "upstream" remote,
+opens the upstream repository.
 You can override this detection with
 "git config %s <DRIVER>"
-where DRIVER is "github", "gitlab", "gitea", or "bitbucket".
+where DRIVER is "github", "gitlab", "gitea", "bitbucket", "bitbucket-server", or "azure".
 
 When using SSH identities, run
 "git config %s <HOSTNAME>"
@@ -10,14 +10,22 @@ import (
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/git-town/git-town/v7/src/runstate"
+	"github.com/git-town/git-town/v7/src/shipmessage"
 	"github.com/git-town/git-town/v7/src/steps"
+	"github.com/git-town/git-town/v7/src/stringslice"
 	"github.com/spf13/cobra"
 )
 
 func shipCmd(repo *git.ProdRepo) *cobra.Command {
+	var autoFlag bool
 	var commitMessage string
+	var ignoreChecksFlag bool
+	var stackFlag bool
+	var strategyFlag string
+	var squashAuthorModeFlag string
+	var dryRunFlag bool
 	shipCmd := cobra.Command{
-		Use:   "ship",
+		Use:   "ship [<branch>...]",
 		Short: "Deliver a completed feature branch",
 		Long: fmt.Sprintf(`Deliver a completed feature branch
 
@@ -32,25 +40,112 @@ into the main branch, resulting in linear history on the main branch.
 - pushes the main branch to the origin repository
 - deletes <branch_name> from the local and origin repositories
 
+To merge shipped branches differently, set the ship strategy via
+"git config %s <squash|merge|rebase|fast-forward>"
+or override it for a single ship with the "--strategy" flag:
+- "squash" (the default) squash-merges the branch into a single commit
+- "merge" merges the branch with a merge commit
+- "rebase" rebases the commits of the branch onto the main branch
+  and fast-forwards the main branch to them
+- "fast-forward" fast-forwards the main branch to the branch
+The "rebase" and "fast-forward" strategies keep the original commits
+and therefore don't use a commit message.
+
+When squash-merging a branch with multiple authors,
+Git Town asks which of them should author the squash commit.
+To credit all of them instead, set the squash author mode via
+"git config %s <choose|branch-owner|current-user>"
+or override it for a single ship with the "--squash-author-mode" flag:
+- "choose" (the default) asks which author to use
+- "branch-owner" makes the author of the first commit on the branch the author
+- "current-user" makes you the author
+The "branch-owner" and "current-user" modes add "Co-authored-by" trailers
+for all other authors of the branch and require Git 2.32 or higher.
+They use the identities from the ".mailmap" file to recognize people with multiple identities.
+
+To pre-fill the commit messages of squash commits, configure a template via
+"git config %s <template>".
+It can contain the placeholders {{branch}}, {{proposal-number}}, {{proposal-title}},
+{{proposal-body}}, {{commits}}, {{issues}}, and {{co-authors}}.
+
 Ships direct children of the main branch.
 To ship a nested child branch, ship or kill all ancestor branches first.
+To ship several independent branches at once, provide all of them.
+This syncs the main branch only once and then syncs, squash-merges, and pushes one branch after the other.
+If a branch runs into merge conflicts, resolve them and run "git town continue",
+or run "git town skip" to leave this branch unshipped and ship the remaining branches.
+With the "--stack" flag, ships the branch together with all its ancestor branches,
+starting with the branch nearest the main branch.
+After shipping each branch, updates the proposals of its child branches
+to target the main branch and syncs the next branch with the main branch.
+Please enter the commit message for each shipped branch into the editor,
+the "--message" flag isn't available in this mode.
+
+With the "--auto" flag, lets the code hosting service merge the proposal
+as soon as all its requirements like passing CI are met.
+This enables auto-merge on GitHub or "merge when pipeline succeeds" on GitLab,
+or adds the proposal to the merge queue or merge train of the target branch.
+Git Town syncs and pushes the branch but keeps it,
+the next "git town sync" removes it once its proposal is merged.
+The commit message is the one given via "--message",
+the one generated from the message template, or the default proposal message.
+
+Before merging a branch that has a proposal, Git Town verifies that
+its CI jobs and the checks required by the target branch have passed
+and that it has the required number of approvals.
+If not, it lists the problems and asks whether to ship anyway.
+Provide the "--ignore-checks" flag to ship without verifying the proposal.
+
+If the origin remote is a fork of the repository at the "upstream" remote,
+Git Town ships branches into the main branch
+only via their proposals in the upstream repository.
 
 If you use GitHub, this command can squash merge pull requests via the GitHub API. Setup:
 1. Get a GitHub personal access token with the "repo" scope
 2. Run 'git config %s <token>' (optionally add the '--global' flag)
 Now anytime you ship a branch with a pull request on GitHub, it will squash merge via the GitHub API.
 It will also update the base branch for any pull requests against that branch.
+Bitbucket works the same way with an access token stored in '%s',
+Azure DevOps with a personal access token stored in '%s'.
 
 If your origin server deletes shipped branches, for example
 GitHub's feature to automatically delete head branches,
 run "git config %s false"
-and Git Town will leave it up to your origin server to delete the remote branch.`, config.GithubTokenKey, config.ShipDeleteRemoteBranchKey),
+and Git Town will leave it up to your origin server to delete the remote branch.`, config.ShipStrategyKey, config.SquashAuthorModeKey, config.ShipMessageTemplateKey, config.GithubTokenKey, config.BitbucketTokenKey, config.AzureTokenKey, config.ShipDeleteRemoteBranchKey),
 		Run: func(cmd *cobra.Command, args []string) {
+			if autoFlag && stackFlag {
+				cli.Exit(fmt.Errorf("the --auto flag cannot be combined with --stack because the code hosting service merges each proposal on its own"))
+			}
+			if stackFlag && commitMessage != "" {
+				cli.Exit(fmt.Errorf("the --message flag cannot be combined with --stack because each shipped branch needs its own commit message"))
+			}
+			if len(args) > 1 && stackFlag {
+				cli.Exit(fmt.Errorf("the --stack flag ships the ancestors of a single branch, it cannot be combined with multiple branches"))
+			}
+			if len(args) > 1 && commitMessage != "" {
+				cli.Exit(fmt.Errorf("the --message flag cannot be combined with multiple branches because each shipped branch needs its own commit message"))
+			}
+			strategy, err := determineShipStrategy(strategyFlag, repo)
+			if err != nil {
+				cli.Exit(err)
+			}
+			if commitMessage != "" && !strategy.CreatesCommit() {
+				cli.Exit(fmt.Errorf("the --message flag doesn't apply to the %q ship strategy because it keeps the original commits", strategy))
+			}
+			squashAuthorMode, err := determineSquashAuthorMode(squashAuthorModeFlag, strategy, repo)
+			if err != nil {
+				cli.Exit(err)
+			}
+			messageTemplate := repo.Config.ShipMessageTemplate()
+			err = shipmessage.Validate(messageTemplate)
+			if err != nil {
+				cli.Exit(err)
+			}
 			connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
 			if err != nil {
 				cli.Exit(err)
 			}
-			config, err := determineShipConfig(args, connector, repo)
+			config, err := determineShipConfig(args, stackFlag, autoFlag, ignoreChecksFlag, strategy, squashAuthorMode, messageTemplate, connector, repo)
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -59,41 +154,66 @@ and Git Town will leave it up to your origin server to delete the remote branch.
 				cli.Exit(err)
 			}
 			runState := runstate.New("ship", stepList)
+			runState.CanSkipBranches = config.shipsIndependentBranches()
 			err = runstate.Execute(runState, repo, connector)
 			if err != nil {
 				cli.Exit(err)
 			}
 		},
-		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
+			}
 			return validateIsConfigured(repo)
 		},
 		GroupID: "basic",
 	}
+	shipCmd.Flags().BoolVar(&autoFlag, "auto", false, "Let the code hosting service merge the proposal once all its requirements are met")
+	shipCmd.Flags().BoolVar(&ignoreChecksFlag, "ignore-checks", false, "Ship even if CI fails or the proposal lacks approvals")
 	shipCmd.Flags().StringVarP(&commitMessage, "message", "m", "", "Specify the commit message for the squash commit")
+	shipCmd.Flags().BoolVar(&stackFlag, "stack", false, "Ship the branch together with all its ancestor branches")
+	shipCmd.Flags().StringVar(&strategyFlag, "strategy", "", "Merge the branch with the given ship strategy: squash, merge, rebase, or fast-forward")
+	shipCmd.Flags().StringVar(&squashAuthorModeFlag, "squash-author-mode", "", "Determine the authors of squash commits with the given mode: choose, branch-owner, or current-user")
+	addDryRunFlag(&shipCmd, &dryRunFlag)
 	return &shipCmd
 }
 
 type shipConfig struct {
+	branches                []shipBranchConfig // the branches to ship, in the order to ship them
+	deleteOriginBranch      bool
+	hasOrigin               bool
+	initialBranch           string
+	isAuto                  bool
+	isFork                  bool
+	isStack                 bool
+	isShippingInitialBranch bool
+	isOffline               bool
+	messageTemplate         string
+	squashAuthorMode        config.SquashAuthorMode
+	strategy                config.ShipStrategy
+}
+
+// shipsIndependentBranches indicates whether this config ships several branches that don't depend on each other.
+func (sc *shipConfig) shipsIndependentBranches() bool {
+	return len(sc.branches) > 1 && !sc.isStack
+}
+
+// shipBranchConfig contains the information needed to ship an individual branch.
+type shipBranchConfig struct {
 	branchToShip             string
 	branchToMergeInto        string // TODO: rename to parentBranch
 	canShipViaAPI            bool
 	childBranches            []string
 	defaultProposalMessage   string // TODO: rename to proposalMessage
-	deleteOriginBranch       bool
-	hasOrigin                bool
 	hasTrackingBranch        bool
-	initialBranch            string
-	isShippingInitialBranch  bool
-	isOffline                bool
 	proposal                 *hosting.Proposal
 	proposalsOfChildBranches []hosting.Proposal
 }
 
-func determineShipConfig(args []string, connector hosting.Connector, repo *git.ProdRepo) (*shipConfig, error) {
+func determineShipConfig(args []string, stack, auto, ignoreChecks bool, strategy config.ShipStrategy, squashAuthorMode config.SquashAuthorMode, messageTemplate string, connector hosting.Connector, repo *git.ProdRepo) (*shipConfig, error) {
 	hasOrigin, err := repo.Silent.HasOrigin()
 	if err != nil {
 		return nil, err
@@ -110,13 +230,11 @@ func determineShipConfig(args []string, connector hosting.Connector, repo *git.P
 	if err != nil {
 		return nil, err
 	}
-	var branchToShip string
-	if len(args) > 0 {
-		branchToShip = args[0]
-	} else {
-		branchToShip = initialBranch
+	requestedBranches := args
+	if len(requestedBranches) == 0 {
+		requestedBranches = []string{initialBranch}
 	}
-	isShippingInitialBranch := branchToShip == initialBranch
+	isShippingInitialBranch := stringslice.Contains(requestedBranches, initialBranch) || (stack && repo.Config.IsAncestorBranch(requestedBranches[0], initialBranch))
 	if isShippingInitialBranch {
 		hasOpenChanges, err := repo.Silent.HasOpenChanges()
 		if err != nil {
@@ -132,29 +250,143 @@ func determineShipConfig(args []string, connector hosting.Connector, repo *git.P
 			return nil, err
 		}
 	}
-	if !isShippingInitialBranch {
-		hasBranch, err := repo.Silent.HasLocalOrOriginBranch(branchToShip)
+	for b, branchToShip := range requestedBranches {
+		if stringslice.Contains(requestedBranches[:b], branchToShip) {
+			return nil, fmt.Errorf("the branch %q is listed more than once", branchToShip)
+		}
+		if branchToShip != initialBranch {
+			hasBranch, err := repo.Silent.HasLocalOrOriginBranch(branchToShip)
+			if err != nil {
+				return nil, err
+			}
+			if !hasBranch {
+				return nil, fmt.Errorf("there is no branch named %q", branchToShip)
+			}
+		}
+		if !repo.Config.IsFeatureBranch(branchToShip) {
+			return nil, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can be shipped", branchToShip)
+		}
+	}
+	parentDialog := dialog.ParentBranches{}
+	err = parentDialog.EnsureKnowsParentBranches(requestedBranches, repo)
+	if err != nil {
+		return nil, err
+	}
+	branchesToShip := requestedBranches
+	if stack {
+		branchesToShip = append(featureAncestorBranches(requestedBranches[0], repo), requestedBranches[0])
+	} else {
+		for _, branchToShip := range requestedBranches {
+			ensureParentBranchIsMainOrPerennialBranch(branchToShip, repo)
+		}
+	}
+	branches := make([]shipBranchConfig, len(branchesToShip))
+	for b, branch := range branchesToShip {
+		// when shipping a stack, all branches get shipped into the parent of the oldest branch to ship,
+		// the ancestors of the other branches are shipped into it before them
+		branchToMergeInto := repo.Config.ParentBranch(branch)
+		if stack {
+			branchToMergeInto = repo.Config.ParentBranch(branchesToShip[0])
+		}
+		branchConfig, err := determineShipBranchConfig(branch, branchToMergeInto, isOffline, connector, repo)
 		if err != nil {
 			return nil, err
 		}
-		if !hasBranch {
-			return nil, fmt.Errorf("there is no branch named %q", branchToShip)
+		// changes to the main branch of a fork go through proposals in the upstream repo
+		if connector != nil && connector.IsFork() && !branchConfig.canShipViaAPI && branchToMergeInto == repo.Config.MainBranch() {
+			return nil, fmt.Errorf("cannot ship branch %q into the main branch of your fork, please ship it via a proposal in the upstream repository", branch)
 		}
+		if auto && !branchConfig.canShipViaAPI {
+			return nil, fmt.Errorf("cannot merge branch %q automatically because it has no proposal that Git Town can merge via the API of your code hosting service", branch)
+		}
+		// the code hosting service verifies the proposal itself before merging it automatically
+		if branchConfig.proposal != nil && !auto && !ignoreChecks {
+			err = ensureProposalIsReady(branch, *branchConfig.proposal, connector)
+			if err != nil {
+				return nil, err
+			}
+		}
+		branches[b] = *branchConfig
+	}
+	return &shipConfig{
+		branches:                branches,
+		deleteOriginBranch:      deleteOrigin,
+		hasOrigin:               hasOrigin,
+		initialBranch:           initialBranch,
+		isAuto:                  auto,
+		isFork:                  connector != nil && connector.IsFork(),
+		isOffline:               isOffline,
+		isStack:                 stack,
+		isShippingInitialBranch: isShippingInitialBranch,
+		messageTemplate:         messageTemplate,
+		squashAuthorMode:        squashAuthorMode,
+		strategy:                strategy,
+	}, nil
+}
+
+// ensureProposalIsReady verifies that the CI jobs and reviews of the given proposal allow shipping the given branch.
+// If not, it asks the user whether to ship the branch anyway.
+func ensureProposalIsReady(branch string, proposal hosting.Proposal, connector hosting.Connector) error {
+	checks, err := connector.ProposalChecks(proposal.Number)
+	if err != nil {
+		return fmt.Errorf("cannot determine the checks of the proposal for branch %q: %w", branch, err)
 	}
-	if !repo.Config.IsFeatureBranch(branchToShip) {
-		return nil, fmt.Errorf("the branch %q is not a feature branch. Only feature branches can be shipped", branchToShip)
+	problems := checks.Problems()
+	if len(problems) == 0 {
+		return nil
 	}
-	parentDialog := dialog.ParentBranches{}
-	err = parentDialog.EnsureKnowsParentBranches([]string{branchToShip}, repo)
+	shipAnyway, err := dialog.ConfirmShipDespiteProblems(branch, problems)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	ensureParentBranchIsMainOrPerennialBranch(branchToShip, repo)
+	if !shipAnyway {
+		return fmt.Errorf("aborted shipping branch %q because its proposal isn't ready", branch)
+	}
+	return nil
+}
+
+// determineShipStrategy provides the ship strategy given via the "--strategy" flag,
+// or the configured one if the flag isn't given.
+func determineShipStrategy(strategyFlag string, repo *git.ProdRepo) (config.ShipStrategy, error) {
+	if strategyFlag == "" {
+		return repo.Config.ShipStrategy()
+	}
+	return config.NewShipStrategy(strategyFlag)
+}
+
+// determineSquashAuthorMode provides the squash author mode given via the "--squash-author-mode" flag,
+// or the configured one if the flag isn't given.
+// Verifies that the installed Git version can add the co-authors that the mode requires when shipping with the given strategy.
+func determineSquashAuthorMode(modeFlag string, strategy config.ShipStrategy, repo *git.ProdRepo) (config.SquashAuthorMode, error) {
+	var mode config.SquashAuthorMode
+	var err error
+	if modeFlag == "" {
+		mode, err = repo.Config.SquashAuthorMode()
+	} else {
+		mode, err = config.NewSquashAuthorMode(modeFlag)
+	}
+	if err != nil {
+		return mode, err
+	}
+	if strategy != config.ShipStrategySquash || !mode.AddsCoAuthors() {
+		return mode, nil
+	}
+	majorVersion, minorVersion, err := repo.Silent.Version()
+	if err != nil {
+		return mode, err
+	}
+	if !CanAddTrailers(majorVersion, minorVersion) {
+		return mode, fmt.Errorf("the %q squash author mode requires Git 2.32 or higher", mode)
+	}
+	return mode, nil
+}
+
+// determineShipBranchConfig provides the information to ship the given branch into the given branch.
+func determineShipBranchConfig(branchToShip, branchToMergeInto string, isOffline bool, connector hosting.Connector, repo *git.ProdRepo) (*shipBranchConfig, error) {
 	hasTrackingBranch, err := repo.Silent.HasTrackingBranch(branchToShip)
 	if err != nil {
 		return nil, err
 	}
-	branchToMergeInto := repo.Config.ParentBranch(branchToShip)
 	canShipViaAPI := false
 	defaultProposalMessage := ""
 	var proposal *hosting.Proposal
@@ -162,7 +394,9 @@ func determineShipConfig(args []string, connector hosting.Connector, repo *git.P
 	proposalsOfChildBranches := []hosting.Proposal{}
 	if !isOffline && connector != nil {
 		if hasTrackingBranch {
-			proposal, err = connector.FindProposal(branchToShip, branchToMergeInto)
+			// the proposal targets the current parent branch,
+			// ancestor branches shipped earlier retarget it to the branch to merge into
+			proposal, err = connector.FindProposal(branchToShip, repo.Config.ParentBranch(branchToShip))
 			if err != nil {
 				return nil, err
 			}
@@ -181,18 +415,13 @@ func determineShipConfig(args []string, connector hosting.Connector, repo *git.P
 			}
 		}
 	}
-	return &shipConfig{
+	return &shipBranchConfig{
 		branchToMergeInto:        branchToMergeInto,
 		branchToShip:             branchToShip,
 		canShipViaAPI:            canShipViaAPI,
 		childBranches:            childBranches,
 		defaultProposalMessage:   defaultProposalMessage,
-		deleteOriginBranch:       deleteOrigin,
-		hasOrigin:                hasOrigin,
 		hasTrackingBranch:        hasTrackingBranch,
-		initialBranch:            initialBranch,
-		isOffline:                isOffline,
-		isShippingInitialBranch:  isShippingInitialBranch,
 		proposal:                 proposal,
 		proposalsOfChildBranches: proposalsOfChildBranches,
 	}, nil
@@ -209,54 +438,144 @@ please ship %q first`, strings.Join(ancestorsWithoutMainOrPerennial, ", "), olde
 	}
 }
 
+// featureAncestorBranches provides the ancestor branches of the given branch that are feature branches,
+// starting with the one nearest the main branch.
+func featureAncestorBranches(branch string, repo *git.ProdRepo) []string {
+	result := []string{}
+	for _, ancestor := range repo.Config.AncestorBranches(branch) {
+		if repo.Config.IsFeatureBranch(ancestor) {
+			result = append(result, ancestor)
+		}
+	}
+	return result
+}
+
 func shipStepList(config *shipConfig, commitMessage string, repo *git.ProdRepo) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
-	updateBranchSteps(&list, config.branchToMergeInto, true, repo) // sync the parent branch
-	updateBranchSteps(&list, config.branchToShip, false, repo)     // sync the branch to ship locally only
-	list.Add(&steps.EnsureHasShippableChangesStep{Branch: config.branchToShip})
-	list.Add(&steps.CheckoutStep{Branch: config.branchToMergeInto})
-	if config.canShipViaAPI {
+	syncedParents := []string{}
+	for _, branch := range config.branches {
+		if !stringslice.Contains(syncedParents, branch.branchToMergeInto) {
+			updateBranchSteps(&list, branch.branchToMergeInto, true, repo) // sync the parent branch
+			syncedParents = append(syncedParents, branch.branchToMergeInto)
+		}
+		if config.isAuto {
+			autoMergeBranchSteps(&list, branch, config, commitMessage, repo)
+		} else {
+			shipBranchSteps(&list, branch, config, commitMessage, repo)
+		}
+	}
+	if !config.isShippingInitialBranch || config.isAuto {
+		// TODO: check out the main branch here?
+		list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+	} else if config.shipsIndependentBranches() {
+		// end on the parent branch also when skipping the last branch
+		list.Add(&steps.CheckoutStep{Branch: config.branches[len(config.branches)-1].branchToMergeInto})
+	}
+	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: !config.isShippingInitialBranch}, repo)
+	return list.Result()
+}
+
+// shipBranchSteps adds the steps to ship the given branch into its already synced parent branch to the given list.
+func shipBranchSteps(list *runstate.StepListBuilder, branch shipBranchConfig, config *shipConfig, commitMessage string, repo *git.ProdRepo) {
+	// sync the branch to ship locally only
+	list.Add(&steps.CheckoutStep{Branch: branch.branchToShip})
+	updateFeatureBranchWithParentSteps(list, branch.branchToShip, branch.branchToMergeInto, repo)
+	list.Add(&steps.EnsureHasShippableChangesStep{Branch: branch.branchToShip})
+	if branch.canShipViaAPI {
+		list.Add(&steps.CheckoutParentStep{CheckoutStep: steps.CheckoutStep{Branch: branch.branchToMergeInto}})
 		// update the proposals of child branches
-		for _, childProposal := range config.proposalsOfChildBranches {
+		for _, childProposal := range branch.proposalsOfChildBranches {
 			list.Add(&steps.UpdateProposalTargetStep{
 				ProposalNumber: childProposal.Number,
-				NewTarget:      config.branchToMergeInto,
+				NewTarget:      branch.branchToMergeInto,
 				ExistingTarget: childProposal.Target,
 			})
 		}
 		// push
-		list.Add(&steps.PushBranchStep{Branch: config.branchToShip})
+		list.Add(&steps.PushBranchStep{Branch: branch.branchToShip})
 		list.Add(&steps.ConnectorMergeProposalStep{
-			Branch:                 config.branchToShip,
-			ProposalNumber:         config.proposal.Number,
+			Branch:                 branch.branchToShip,
+			ProposalNumber:         branch.proposal.Number,
 			CommitMessage:          commitMessage,
-			DefaultProposalMessage: config.defaultProposalMessage,
+			DefaultProposalMessage: branch.defaultProposalMessage,
+			ProposalBody:           branch.proposal.Body,
+			ProposalTarget:         branch.branchToMergeInto,
+			ProposalTitle:          branch.proposal.Title,
+			Strategy:               config.strategy,
+			AuthorMode:             config.squashAuthorMode,
+			MessageTemplate:        config.messageTemplate,
 		})
-		list.Add(&steps.PullBranchStep{})
+		if config.isFork {
+			// the proposal got merged in the upstream repo
+			list.Add(&steps.FetchUpstreamStep{Branch: branch.branchToMergeInto})
+			list.Add(&steps.RebaseBranchStep{Branch: fmt.Sprintf("upstream/%s", branch.branchToMergeInto)})
+		} else {
+			list.Add(&steps.PullBranchStep{})
+		}
 	} else {
-		list.Add(&steps.SquashMergeStep{Branch: config.branchToShip, CommitMessage: commitMessage})
+		mergeBranchLocallySteps(list, branch, config, commitMessage)
 	}
 	if config.hasOrigin && !config.isOffline {
-		list.Add(&steps.PushBranchStep{Branch: config.branchToMergeInto, Undoable: true})
+		list.Add(&steps.PushBranchStep{Branch: branch.branchToMergeInto, Undoable: true})
 	}
 	// NOTE: when shipping via API, we can always delete the remote branch because:
 	// - we know we have a tracking branch (otherwise there would be no PR to ship via API)
 	// - we have updated the PRs of all child branches (because we have API access)
 	// - we know we are online
-	if config.canShipViaAPI || (config.hasTrackingBranch && len(config.childBranches) == 0 && !config.isOffline) {
+	if branch.canShipViaAPI || (branch.hasTrackingBranch && len(branch.childBranches) == 0 && !config.isOffline) {
 		if config.deleteOriginBranch {
-			list.Add(&steps.DeleteOriginBranchStep{Branch: config.branchToShip, IsTracking: true})
+			list.Add(&steps.DeleteOriginBranchStep{Branch: branch.branchToShip, IsTracking: true})
 		}
 	}
-	list.Add(&steps.DeleteLocalBranchStep{Branch: config.branchToShip})
-	list.Add(&steps.DeleteParentBranchStep{Branch: config.branchToShip})
-	for _, child := range config.childBranches {
-		list.Add(&steps.SetParentStep{Branch: child, ParentBranch: config.branchToMergeInto})
+	list.Add(&steps.DeleteLocalBranchStep{Branch: branch.branchToShip})
+	list.Add(&steps.DeleteParentBranchStep{Branch: branch.branchToShip})
+	list.Add(&steps.DeleteAutoMergeProposalStep{Branch: branch.branchToShip})
+	for _, child := range branch.childBranches {
+		list.Add(&steps.SetParentStep{Branch: child, ParentBranch: branch.branchToMergeInto})
 	}
-	if !config.isShippingInitialBranch {
-		// TODO: check out the main branch here?
-		list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+}
+
+// autoMergeBranchSteps adds the steps to let the code hosting service merge the proposal of the given branch
+// into its already synced parent branch once all requirements of the proposal are met to the given list.
+// The branch stays around until "git sync" sees the merged proposal.
+func autoMergeBranchSteps(list *runstate.StepListBuilder, branch shipBranchConfig, config *shipConfig, commitMessage string, repo *git.ProdRepo) {
+	list.Add(&steps.CheckoutStep{Branch: branch.branchToShip})
+	updateFeatureBranchWithParentSteps(list, branch.branchToShip, branch.branchToMergeInto, repo)
+	list.Add(&steps.EnsureHasShippableChangesStep{Branch: branch.branchToShip})
+	list.Add(&steps.PushBranchStep{Branch: branch.branchToShip})
+	list.Add(&steps.ConnectorAutoMergeProposalStep{
+		AuthorMode:             config.squashAuthorMode,
+		Branch:                 branch.branchToShip,
+		CommitMessage:          commitMessage,
+		DefaultProposalMessage: branch.defaultProposalMessage,
+		MessageTemplate:        config.messageTemplate,
+		ProposalBody:           branch.proposal.Body,
+		ProposalNumber:         branch.proposal.Number,
+		ProposalTitle:          branch.proposal.Title,
+		Strategy:               config.strategy,
+	})
+}
+
+// mergeBranchLocallySteps adds the steps to merge the given checked out branch into its parent branch
+// using the ship strategy, squash author mode, and message template of the given ship config to the given list.
+func mergeBranchLocallySteps(list *runstate.StepListBuilder, branch shipBranchConfig, shipConfig *shipConfig, commitMessage string) {
+	strategy := shipConfig.strategy
+	if strategy == config.ShipStrategyRebase {
+		// replay the commits of the branch onto its parent, so that the parent can fast-forward to them
+		list.Add(&steps.RebaseBranchStep{Branch: branch.branchToMergeInto})
+	}
+	list.Add(&steps.CheckoutParentStep{CheckoutStep: steps.CheckoutStep{Branch: branch.branchToMergeInto}})
+	switch strategy {
+	case config.ShipStrategySquash:
+		list.Add(&steps.SquashMergeStep{
+			AuthorMode:      shipConfig.squashAuthorMode,
+			Branch:          branch.branchToShip,
+			CommitMessage:   commitMessage,
+			MessageTemplate: shipConfig.messageTemplate,
+		})
+	case config.ShipStrategyMerge:
+		list.Add(&steps.MergeNoFastForwardStep{Branch: branch.branchToShip, CommitMessage: commitMessage})
+	case config.ShipStrategyRebase, config.ShipStrategyFastForward:
+		list.Add(&steps.FastForwardStep{Branch: branch.branchToShip, Pushed: shipConfig.hasOrigin && !shipConfig.isOffline})
 	}
-	list.Wrap(runstate.WrapOptions{RunInGitRoot: true, StashOpenChanges: !config.isShippingInitialBranch}, repo)
-	return list.Result()
 }
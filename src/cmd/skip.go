@@ -5,6 +5,7 @@ import (
 
 	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/git-town/git-town/v7/src/runstate"
 	"github.com/spf13/cobra"
 )
@@ -14,18 +15,9 @@ func skipCmd(repo *git.ProdRepo) *cobra.Command {
 		Use:   "skip",
 		Short: "Restarts the last run git-town command by skipping the current branch",
 		Run: func(cmd *cobra.Command, args []string) {
-			runState, err := runstate.Load(repo)
-			if err != nil {
-				cli.Exit(fmt.Errorf("cannot load previous run state: %w", err))
-			}
-			if runState == nil || !runState.IsUnfinished() {
-				cli.Exit(fmt.Errorf("nothing to skip"))
-			}
-			if !runState.UnfinishedDetails.CanSkip {
-				cli.Exit(fmt.Errorf("cannot skip branch that resulted in conflicts"))
-			}
-			skipRunState := runState.CreateSkipRunState()
-			err = runstate.Execute(&skipRunState, repo, nil)
+			err := withLock(repo, func() error {
+				return skipCurrentBranch(repo)
+			})
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -40,3 +32,23 @@ func skipCmd(repo *git.ProdRepo) *cobra.Command {
 		GroupID: "errors",
 	}
 }
+
+// skipCurrentBranch continues the unfinished Git Town command without the remaining steps for the current branch.
+func skipCurrentBranch(repo *git.ProdRepo) error {
+	runState, err := runstate.Load(repo)
+	if err != nil {
+		return fmt.Errorf("cannot load previous run state: %w", err)
+	}
+	if runState == nil || !runState.IsUnfinished() {
+		return fmt.Errorf("nothing to skip")
+	}
+	if !runState.UnfinishedDetails.CanSkip {
+		return fmt.Errorf("cannot skip branch that resulted in conflicts")
+	}
+	connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+	if err != nil {
+		return err
+	}
+	skipRunState := runState.CreateSkipRunState()
+	return runstate.Execute(&skipRunState, repo, connector)
+}
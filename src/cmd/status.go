@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/git-town/git-town/v7/src/cli"
@@ -57,7 +58,7 @@ func loadDisplayStatusConfig(repo *git.ProdRepo) (*displayStatusConfig, error) {
 
 func displayStatus(config displayStatusConfig) {
 	if config.state == nil {
-		fmt.Println("No status file found for this repository.")
+		cli.Println("No status file found for this repository.")
 		return
 	}
 	if config.state.IsUnfinished() {
@@ -65,25 +66,56 @@ func displayStatus(config displayStatusConfig) {
 	} else {
 		displayFinishedStatus(config)
 	}
+	displayUnknownSteps(config)
 }
 
 func displayUnfinishedStatus(config displayStatusConfig) {
 	timeDiff := time.Since(config.state.UnfinishedDetails.EndTime)
-	fmt.Printf("The last Git Town command (%s) hit a problem %v ago.\n", config.state.Command, timeDiff)
+	if config.state.UnfinishedDetails.Interrupted {
+		displayInterruptedStatus(config, timeDiff)
+		return
+	}
+	cli.Printf("The last Git Town command (%s) hit a problem %v ago.\n", config.state.Command, timeDiff)
 	if config.state.HasAbortSteps() {
-		fmt.Println("You can run \"git town abort\" to abort it.")
+		cli.Println("You can run \"git town abort\" to abort it.")
 	}
 	if config.state.HasRunSteps() {
-		fmt.Println("You can run \"git town continue\" to finish it.")
+		cli.Println("You can run \"git town continue\" to finish it.")
 	}
 	if config.state.UnfinishedDetails.CanSkip {
-		fmt.Println("You can run \"git town skip\" to skip the currently failing step.")
+		cli.Println("You can run \"git town skip\" to skip the currently failing step.")
+	}
+}
+
+func displayInterruptedStatus(config displayStatusConfig, timeDiff time.Duration) {
+	cli.Printf("The last Git Town command (%s) got interrupted %v ago.\n", config.state.Command, timeDiff)
+	canAbort := config.state.HasAbortSteps() || config.state.HasUndoSteps()
+	if canAbort {
+		cli.Println("You can run \"git town abort\" to abort it.")
+	}
+	if config.state.HasRunSteps() {
+		cli.Println("You can run \"git town continue\" to finish it.")
+	}
+	if canAbort {
+		cli.Println("You can run \"git town undo\" to undo what it did so far.")
+	}
+}
+
+func displayUnknownSteps(config displayStatusConfig) {
+	unknownSteps := config.state.UnknownSteps()
+	if len(unknownSteps) == 0 {
+		return
+	}
+	cli.Printf("This version of Git Town cannot run these stored steps: %s\n", strings.Join(unknownSteps, ", "))
+	if config.state.HasRunSteps() {
+		cli.Printf("The command still had to run these steps: %s\n", strings.Join(config.state.RunStepList.StepTypes(), ", "))
 	}
+	cli.Println("Aborting or undoing leaves out the stored steps that this version of Git Town cannot run.")
 }
 
 func displayFinishedStatus(config displayStatusConfig) {
-	fmt.Printf("The previous Git Town command (%s) finished successfully.\n", config.state.Command)
+	cli.Printf("The previous Git Town command (%s) finished successfully.\n", config.state.Command)
 	if config.state.HasUndoSteps() {
-		fmt.Println("You can run \"git town undo\" to undo it.")
+		cli.Println("You can run \"git town undo\" to undo it.")
 	}
 }
@@ -10,15 +10,27 @@ import (
 )
 
 func switchCmd(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
+	var statusFlag bool
+	switchCmd := cobra.Command{
 		Use:   "switch",
 		Short: "Displays the local branches visually and allows switching between them",
+		Long: `Displays the local branches visually and allows switching between them
+
+With the "--status" flag, also displays the status of each branch
+like the "branches" command does.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			currentBranch, err := repo.Silent.CurrentBranch()
 			if err != nil {
 				cli.Exit(err)
 			}
-			newBranch, err := queryBranch(currentBranch, repo)
+			var statusConfig *branchStatusConfig
+			if statusFlag {
+				statusConfig, err = determineBranchStatusConfig(repo)
+				if err != nil {
+					cli.Exit(err)
+				}
+			}
+			newBranch, err := queryBranch(currentBranch, statusConfig, repo)
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -38,12 +50,15 @@ func switchCmd(repo *git.ProdRepo) *cobra.Command {
 		},
 		GroupID: "basic",
 	}
+	switchCmd.Flags().BoolVar(&statusFlag, "status", false, "Display the status of each branch")
+	return &switchCmd
 }
 
 // queryBranch lets the user select a new branch via a visual dialog.
+// Annotates the branches with their status if the given statusConfig is not nil.
 // Returns the selected branch or nil if the user aborted.
-func queryBranch(currentBranch string, repo *git.ProdRepo) (selection *string, err error) { //nolint:nonamedreturns
-	entries, err := createEntries(repo)
+func queryBranch(currentBranch string, statusConfig *branchStatusConfig, repo *git.ProdRepo) (selection *string, err error) { //nolint:nonamedreturns
+	entries, err := createEntries(statusConfig, repo)
 	if err != nil {
 		return nil, err
 	}
@@ -51,11 +66,11 @@ func queryBranch(currentBranch string, repo *git.ProdRepo) (selection *string, e
 }
 
 // createEntries provides all the entries for the branch dialog.
-func createEntries(repo *git.ProdRepo) (dialog.ModalEntries, error) {
+func createEntries(statusConfig *branchStatusConfig, repo *git.ProdRepo) (dialog.ModalEntries, error) {
 	entries := dialog.ModalEntries{}
 	var err error
 	for _, root := range repo.Config.BranchAncestryRoots() {
-		entries, err = addEntryAndChildren(entries, root, 0, repo)
+		entries, err = addEntryAndChildren(entries, root, 0, statusConfig, repo)
 		if err != nil {
 			return nil, err
 		}
@@ -64,14 +79,22 @@ func createEntries(repo *git.ProdRepo) (dialog.ModalEntries, error) {
 }
 
 // addEntryAndChildren adds the given branch and all its child branches to the given entries collection.
-func addEntryAndChildren(entries dialog.ModalEntries, branch string, indent int, repo *git.ProdRepo) (dialog.ModalEntries, error) {
+func addEntryAndChildren(entries dialog.ModalEntries, branch string, indent int, statusConfig *branchStatusConfig, repo *git.ProdRepo) (dialog.ModalEntries, error) {
+	text := branch
+	if statusConfig != nil {
+		annotations, err := branchAnnotations(branch, statusConfig, repo)
+		if err != nil {
+			return entries, err
+		}
+		text = annotatedBranchName(branch, annotations)
+	}
 	entries = append(entries, dialog.ModalEntry{
-		Text:  strings.Repeat("  ", indent) + branch,
+		Text:  strings.Repeat("  ", indent) + text,
 		Value: branch,
 	})
 	var err error
 	for _, child := range repo.Silent.Config.ChildBranches(branch) {
-		entries, err = addEntryAndChildren(entries, child, indent+1, repo)
+		entries, err = addEntryAndChildren(entries, child, indent+1, statusConfig, repo)
 		if err != nil {
 			return entries, err
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/dialog"
 	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/git-town/git-town/v7/src/runstate"
 	"github.com/git-town/git-town/v7/src/steps"
 	"github.com/spf13/cobra"
@@ -35,7 +36,10 @@ When run on the main branch or a perennial branch
 
 If the repository contains an "upstream" remote,
 syncs the main branch with its upstream counterpart.
-You can disable this by running "git config %s false".`, config.SyncUpstreamKey),
+You can disable this by running "git config %s false".
+
+Removes branches shipped via "git ship --auto"
+once the code hosting service has merged their proposals.`, config.SyncUpstreamKey),
 		Run: func(cmd *cobra.Command, args []string) {
 			config, err := determineSyncConfig(allFlag, repo)
 			if err != nil {
@@ -46,7 +50,7 @@ You can disable this by running "git config %s false".`, config.SyncUpstreamKey)
 				cli.Exit(err)
 			}
 			runState := runstate.New("sync", stepList)
-			err = runstate.Execute(runState, repo, nil)
+			err = runstate.Execute(runState, repo, config.connector)
 			if err != nil {
 				cli.Exit(err)
 			}
@@ -56,12 +60,8 @@ You can disable this by running "git config %s false".`, config.SyncUpstreamKey)
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
 			}
-			if dryRunFlag {
-				currentBranch, err := repo.Silent.CurrentBranch()
-				if err != nil {
-					return err
-				}
-				repo.DryRun.Activate(currentBranch)
+			if err := activateDryRun(dryRunFlag, repo); err != nil {
+				return err
 			}
 			if err := validateIsConfigured(repo); err != nil {
 				return err
@@ -78,16 +78,41 @@ You can disable this by running "git config %s false".`, config.SyncUpstreamKey)
 		GroupID: "basic",
 	}
 	syncCmd.Flags().BoolVar(&allFlag, "all", false, "Sync all local branches")
-	syncCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Print the commands but don't run them")
+	addDryRunFlag(&syncCmd, &dryRunFlag)
 	return &syncCmd
 }
 
 type syncConfig struct {
-	branchesToSync []string
-	hasOrigin      bool
-	initialBranch  string
-	isOffline      bool
-	shouldPushTags bool
+	autoMergedBranches map[string]autoMergedBranchConfig // the branches to remove because the code hosting service has merged their proposals
+	branchesToSync     []string
+	connector          hosting.Connector
+	deleteOriginBranch bool
+	hasOrigin          bool
+	initialBranch      string
+	isOffline          bool
+	shouldPushTags     bool
+}
+
+// autoMergedBranchConfig contains the information needed to remove a branch
+// whose proposal the code hosting service has merged after "git ship --auto".
+type autoMergedBranchConfig struct {
+	childBranches            []string
+	hasTrackingBranch        bool
+	parentBranch             string
+	proposalsOfChildBranches []hosting.Proposal
+}
+
+// parentBranch provides the branch that the given branch syncs with,
+// skipping ancestors that get removed because their proposals have been merged.
+func (sc *syncConfig) parentBranch(branch string, repo *git.ProdRepo) string {
+	parent := repo.Config.ParentBranch(branch)
+	for {
+		autoMergedParent, isAutoMerged := sc.autoMergedBranches[parent]
+		if !isAutoMerged {
+			return parent
+		}
+		parent = autoMergedParent.parentBranch
+	}
 }
 
 func determineSyncConfig(allFlag bool, repo *git.ProdRepo) (*syncConfig, error) {
@@ -131,22 +156,98 @@ func determineSyncConfig(allFlag bool, repo *git.ProdRepo) (*syncConfig, error)
 		branchesToSync = append(repo.Config.AncestorBranches(initialBranch), initialBranch)
 		shouldPushTags = !repo.Config.IsFeatureBranch(initialBranch)
 	}
+	deleteOriginBranch, err := repo.Config.ShouldShipDeleteOriginBranch()
+	if err != nil {
+		return nil, err
+	}
+	var connector hosting.Connector
+	autoMergedBranches := map[string]autoMergedBranchConfig{}
+	if hasOrigin && !isOffline {
+		connector, autoMergedBranches, err = determineAutoMergedBranches(branchesToSync, repo)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &syncConfig{
-		branchesToSync: branchesToSync,
-		hasOrigin:      hasOrigin,
-		initialBranch:  initialBranch,
-		isOffline:      isOffline,
-		shouldPushTags: shouldPushTags,
+		autoMergedBranches: autoMergedBranches,
+		branchesToSync:     branchesToSync,
+		connector:          connector,
+		deleteOriginBranch: deleteOriginBranch,
+		hasOrigin:          hasOrigin,
+		initialBranch:      initialBranch,
+		isOffline:          isOffline,
+		shouldPushTags:     shouldPushTags,
 	}, nil
 }
 
+// determineAutoMergedBranches provides the branches among the given ones
+// whose proposals the code hosting service has merged after "git ship --auto",
+// together with the connector to the code hosting service if any branch waits for such a merge.
+//
+//nolint:nonamedreturns
+func determineAutoMergedBranches(branches []string, repo *git.ProdRepo) (connector hosting.Connector, result map[string]autoMergedBranchConfig, err error) {
+	result = map[string]autoMergedBranchConfig{}
+	for _, branch := range branches {
+		proposalNumber := repo.Config.AutoMergeProposal(branch)
+		if proposalNumber == 0 {
+			continue
+		}
+		if connector == nil {
+			connector, err = hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+			if err != nil {
+				return nil, nil, err
+			}
+			if connector == nil {
+				return nil, result, nil
+			}
+		}
+		merged, err := connector.ProposalMerged(proposalNumber)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot determine whether the proposal for branch %q is merged: %w", branch, err)
+		}
+		if !merged {
+			continue
+		}
+		hasTrackingBranch, err := repo.Silent.HasTrackingBranch(branch)
+		if err != nil {
+			return nil, nil, err
+		}
+		childBranches := repo.Config.ChildBranches(branch)
+		proposalsOfChildBranches := []hosting.Proposal{}
+		for _, childBranch := range childBranches {
+			childProposal, err := connector.FindProposal(childBranch, branch)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot determine proposal for branch %q: %w", childBranch, err)
+			}
+			if childProposal != nil {
+				proposalsOfChildBranches = append(proposalsOfChildBranches, *childProposal)
+			}
+		}
+		result[branch] = autoMergedBranchConfig{
+			childBranches:            childBranches,
+			hasTrackingBranch:        hasTrackingBranch,
+			parentBranch:             repo.Config.ParentBranch(branch),
+			proposalsOfChildBranches: proposalsOfChildBranches,
+		}
+	}
+	return connector, result, nil
+}
+
 // syncBranchesSteps provides the step list for the "git sync" command.
 func syncBranchesSteps(config *syncConfig, repo *git.ProdRepo) (runstate.StepList, error) {
 	list := runstate.StepListBuilder{}
 	for _, branch := range config.branchesToSync {
-		updateBranchSteps(&list, branch, true, repo)
+		if autoMergedBranch, isAutoMerged := config.autoMergedBranches[branch]; isAutoMerged {
+			removeAutoMergedBranchSteps(&list, branch, autoMergedBranch, config)
+			continue
+		}
+		updateBranchWithParentSteps(&list, branch, config.parentBranch(branch, repo), true, repo)
+	}
+	finalBranch := config.initialBranch
+	if _, isAutoMerged := config.autoMergedBranches[config.initialBranch]; isAutoMerged {
+		finalBranch = config.parentBranch(config.initialBranch, repo)
 	}
-	list.Add(&steps.CheckoutStep{Branch: config.initialBranch})
+	list.Add(&steps.CheckoutStep{Branch: finalBranch})
 	if config.hasOrigin && config.shouldPushTags && !config.isOffline {
 		list.Add(&steps.PushTagsStep{})
 	}
@@ -154,8 +255,37 @@ func syncBranchesSteps(config *syncConfig, repo *git.ProdRepo) (runstate.StepLis
 	return list.Result()
 }
 
+// removeAutoMergedBranchSteps provides the steps to remove the given branch
+// whose proposal the code hosting service has merged after "git ship --auto".
+func removeAutoMergedBranchSteps(list *runstate.StepListBuilder, branch string, autoMergedBranch autoMergedBranchConfig, config *syncConfig) {
+	list.Add(&steps.CheckoutStep{Branch: autoMergedBranch.parentBranch})
+	if autoMergedBranch.hasTrackingBranch && config.deleteOriginBranch {
+		list.Add(&steps.DeleteOriginBranchStep{Branch: branch, IsTracking: true})
+	}
+	// the code hosting service might have merged the branch with different commits
+	list.Add(&steps.DeleteLocalBranchStep{Branch: branch, Force: true})
+	list.Add(&steps.DeleteParentBranchStep{Branch: branch})
+	list.Add(&steps.DeleteAutoMergeProposalStep{Branch: branch})
+	for _, childProposal := range autoMergedBranch.proposalsOfChildBranches {
+		list.Add(&steps.UpdateProposalTargetStep{
+			ProposalNumber: childProposal.Number,
+			NewTarget:      autoMergedBranch.parentBranch,
+			ExistingTarget: childProposal.Target,
+		})
+	}
+	for _, child := range autoMergedBranch.childBranches {
+		list.Add(&steps.SetParentStep{Branch: child, ParentBranch: autoMergedBranch.parentBranch})
+	}
+}
+
 // updateBranchSteps provides the steps to sync a particular branch.
 func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch bool, repo *git.ProdRepo) {
+	updateBranchWithParentSteps(list, branch, repo.Config.ParentBranch(branch), pushBranch, repo)
+}
+
+// updateBranchWithParentSteps provides the steps to sync a particular branch,
+// syncing feature branches with the given parent branch.
+func updateBranchWithParentSteps(list *runstate.StepListBuilder, branch, parentBranch string, pushBranch bool, repo *git.ProdRepo) {
 	isFeatureBranch := repo.Config.IsFeatureBranch(branch)
 	syncStrategy := list.SyncStrategy(repo.Config.SyncStrategy())
 	hasOrigin := list.Bool(repo.Silent.HasOrigin())
@@ -165,7 +295,7 @@ func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch
 	}
 	list.Add(&steps.CheckoutStep{Branch: branch})
 	if isFeatureBranch {
-		updateFeatureBranchSteps(list, branch, repo)
+		updateFeatureBranchWithParentSteps(list, branch, parentBranch, repo)
 	} else {
 		updatePerennialBranchSteps(list, branch, repo)
 	}
@@ -184,13 +314,15 @@ func updateBranchSteps(list *runstate.StepListBuilder, branch string, pushBranch
 	}
 }
 
-func updateFeatureBranchSteps(list *runstate.StepListBuilder, branch string, repo *git.ProdRepo) {
+// updateFeatureBranchWithParentSteps provides the steps to sync the given feature branch
+// with its tracking branch and the given parent branch.
+func updateFeatureBranchWithParentSteps(list *runstate.StepListBuilder, branch, parentBranch string, repo *git.ProdRepo) {
 	syncStrategy := list.SyncStrategy(repo.Config.SyncStrategy())
 	hasTrackingBranch := list.Bool(repo.Silent.HasTrackingBranch(branch))
 	if hasTrackingBranch {
 		syncBranchSteps(list, repo.Silent.TrackingBranch(branch), string(syncStrategy))
 	}
-	syncBranchSteps(list, repo.Config.ParentBranch(branch), string(syncStrategy))
+	syncBranchSteps(list, parentBranch, string(syncStrategy))
 }
 
 func updatePerennialBranchSteps(list *runstate.StepListBuilder, branch string, repo *git.ProdRepo) {
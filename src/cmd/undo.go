@@ -2,32 +2,54 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/git-town/git-town/v7/src/runstate"
 	"github.com/spf13/cobra"
 )
 
 func undoCmd(repo *git.ProdRepo) *cobra.Command {
-	return &cobra.Command{
-		Use:   "undo",
+	var listFlag bool
+	undoCmd := cobra.Command{
+		Use:   "undo [<number of commands>]",
 		Short: "Undoes the last run git-town command",
+		Long: `Undoes the last run git-town command
+
+Git Town remembers the commands that finished in each repository.
+When given a number, undoes that many of them, starting with the most recent one.
+The "--list" flag displays the commands that can be undone.
+
+Git Town refuses to undo a command if the branches that it changed
+have changed again since then, because undoing the command would discard those changes.
+
+If Git Town got interrupted or terminated while running a command,
+undoes the changes that this command made until then.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			runState, err := runstate.Load(repo)
-			if err != nil {
-				cli.Exit(fmt.Errorf("cannot load previous run state: %w", err))
+			var err error
+			switch {
+			case listFlag:
+				err = printUndoJournal(repo)
+			case len(args) == 0:
+				err = withLock(repo, func() error {
+					return undoLastCommand(repo)
+				})
+			default:
+				count, parseErr := strconv.Atoi(args[0])
+				if parseErr != nil || count < 1 {
+					cli.Exit(fmt.Errorf("invalid number of commands to undo: %q", args[0]))
+				}
+				err = withLock(repo, func() error {
+					return undoCommands(count, repo)
+				})
 			}
-			if runState == nil || runState.IsUnfinished() {
-				cli.Exit(fmt.Errorf("nothing to undo"))
-			}
-			undoRunState := runState.CreateUndoRunState()
-			err = runstate.Execute(&undoRunState, repo, nil)
 			if err != nil {
 				cli.Exit(err)
 			}
 		},
-		Args: cobra.NoArgs,
+		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if err := ValidateIsRepository(repo); err != nil {
 				return err
@@ -36,4 +58,106 @@ func undoCmd(repo *git.ProdRepo) *cobra.Command {
 		},
 		GroupID: "errors",
 	}
+	undoCmd.Flags().BoolVar(&listFlag, "list", false, "Display the commands that can be undone")
+	return &undoCmd
+}
+
+// printUndoJournal displays the commands that can be undone, most recent first.
+func printUndoJournal(repo *git.ProdRepo) error {
+	journal, err := runstate.LoadJournal(repo)
+	if err != nil {
+		return err
+	}
+	if len(journal.Entries) == 0 {
+		cli.Println("There are no commands to undo.")
+		return nil
+	}
+	for e, entry := range journal.Entries {
+		cli.Printf("%d  %s  %s\n", e+1, entry.EndTime.Local().Format("2006-01-02 15:04:05"), entry.Description())
+	}
+	return nil
+}
+
+// undoLastCommand undoes the most recently run Git Town command.
+func undoLastCommand(repo *git.ProdRepo) error {
+	runState, err := runstate.Load(repo)
+	if err != nil {
+		return fmt.Errorf("cannot load previous run state: %w", err)
+	}
+	if runState != nil && runState.IsUnfinished() && runState.UnfinishedDetails.Interrupted {
+		// undoing an interrupted command undoes the changes it made before it got interrupted
+		abortRunState := runState.CreateAbortRunState()
+		connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+		if err != nil {
+			return err
+		}
+		return runstate.Execute(&abortRunState, repo, connector)
+	}
+	if runState != nil && runState.IsUnfinished() {
+		return fmt.Errorf("nothing to undo")
+	}
+	journal, err := runstate.LoadJournal(repo)
+	if err != nil {
+		return err
+	}
+	if len(journal.Entries) > 0 {
+		// undoing a command removes the run state, the journal still contains the commands before it
+		return undoCommands(1, repo)
+	}
+	if runState == nil {
+		return fmt.Errorf("nothing to undo")
+	}
+	// the run state was stored by a Git Town version that didn't keep a journal
+	undoRunState := runState.CreateUndoRunState()
+	connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+	if err != nil {
+		return err
+	}
+	return runstate.Execute(&undoRunState, repo, connector)
+}
+
+// undoCommands undoes the given number of most recently run Git Town commands.
+func undoCommands(count int, repo *git.ProdRepo) error {
+	runState, err := runstate.Load(repo)
+	if err != nil {
+		return fmt.Errorf("cannot load previous run state: %w", err)
+	}
+	if runState != nil && runState.IsUnfinished() {
+		return fmt.Errorf("cannot undo commands while the %q command is unfinished, please continue or abort it first", runState.Command)
+	}
+	journal, err := runstate.LoadJournal(repo)
+	if err != nil {
+		return err
+	}
+	if count > len(journal.Entries) {
+		return fmt.Errorf("cannot undo %d commands because Git Town remembers only %d", count, len(journal.Entries))
+	}
+	return undoJournalEntries(count, journal, repo)
+}
+
+// undoJournalEntries undoes the given number of entries in the given journal, newest first.
+func undoJournalEntries(count int, journal *runstate.Journal, repo *git.ProdRepo) error {
+	connector, err := hosting.NewConnector(&repo.Config, &repo.Silent, cli.PrintConnectorAction)
+	if err != nil {
+		return err
+	}
+	for e, entry := range journal.Entries[:count] {
+		err = entry.EnsureIsUndoable(repo)
+		if err != nil {
+			if e > 0 {
+				return fmt.Errorf("undid %d of %d commands: %w", e, count, err)
+			}
+			return err
+		}
+		undoRunState := entry.RunState.CreateUndoRunState()
+		err = runstate.Execute(&undoRunState, repo, connector)
+		if err != nil {
+			return err
+		}
+		err = runstate.RemoveLatestFromJournal(repo)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
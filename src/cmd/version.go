@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +18,7 @@ func versionCmd() *cobra.Command {
 		Short: "Displays the version",
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("Git Town %s (%s)\n", version, buildDate)
+			cli.Printf("Git Town %s (%s)\n", version, buildDate)
 		},
 		GroupID: "setup",
 	}
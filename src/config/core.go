@@ -19,9 +19,12 @@ import (
 )
 
 const (
+	AzureTokenKey                = "git-town.azure-token"     //nolint:gosec
+	BitbucketTokenKey            = "git-town.bitbucket-token" //nolint:gosec
 	CodeHostingDriverKey         = "git-town.code-hosting-driver"
 	CodeHostingOriginHostnameKey = "git-town.code-hosting-origin-hostname"
-	GiteaTokenKey                = "git-town.gitea-token"  //nolint:gosec
+	GiteaTokenKey                = "git-town.gitea-token" //nolint:gosec
+	GithubAPIURLKey              = "git-town.github-api-url"
 	GithubTokenKey               = "git-town.github-token" //nolint:gosec
 	GitlabTokenKey               = "git-town.gitlab-token" //nolint:gosec
 	MainBranchKey                = "git-town.main-branch-name"
@@ -31,7 +34,11 @@ const (
 	PullBranchStrategyKey        = "git-town.pull-branch-strategy"
 	PushHookKey                  = "git-town.push-hook"
 	PushNewBranchesKey           = "git-town.push-new-branches"
+	RunstateLocationKey          = "git-town.runstate-location"
 	ShipDeleteRemoteBranchKey    = "git-town.ship-delete-remote-branch"
+	ShipMessageTemplateKey       = "git-town.ship-message-template"
+	ShipStrategyKey              = "git-town.ship-strategy"
+	SquashAuthorModeKey          = "git-town.squash-author-mode"
 	SyncUpstreamKey              = "git-town.sync-upstream"
 	SyncStrategyKey              = "git-town.sync-strategy"
 	TestingRemoteURLKey          = "git-town.testing.remote-url"
@@ -40,14 +47,16 @@ const (
 // GitTown provides type-safe access to Git Town configuration settings
 // stored in the local and global Git configuration.
 type GitTown struct {
-	Storage        Git
-	originURLCache map[string]*giturl.Parts
+	Storage         Git
+	credentialCache map[string]string // hostname --> password provided by the Git credential helpers
+	originURLCache  map[string]*giturl.Parts
 }
 
 func NewGitTown(shell run.Shell) GitTown {
 	return GitTown{
-		Storage:        NewGit(shell),
-		originURLCache: map[string]*giturl.Parts{},
+		Storage:         NewGit(shell),
+		credentialCache: map[string]string{},
+		originURLCache:  map[string]*giturl.Parts{},
 	}
 }
 
@@ -82,6 +91,27 @@ func (gt *GitTown) AncestorBranches(branch string) []string {
 	}
 }
 
+// AutoMergeProposal provides the number of the proposal for the given branch
+// that "git ship --auto" has set up to get merged automatically by the code hosting service.
+// Returns 0 if the branch doesn't wait for an automatic merge.
+func (gt *GitTown) AutoMergeProposal(branch string) int {
+	number, err := strconv.Atoi(gt.Storage.LocalConfigValue("git-town-branch." + branch + ".auto-merge-proposal"))
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// AzureToken provides the content of the Azure DevOps personal access token stored in the local or global Git Town configuration.
+func (gt *GitTown) AzureToken() string {
+	return gt.Storage.LocalOrGlobalConfigValue(AzureTokenKey)
+}
+
+// BitbucketToken provides the content of the Bitbucket API token stored in the local or global Git Town configuration.
+func (gt *GitTown) BitbucketToken() string {
+	return gt.Storage.LocalOrGlobalConfigValue(BitbucketTokenKey)
+}
+
 // BranchAncestryRoots provides the branches with children and no parents.
 func (gt *GitTown) BranchAncestryRoots() []string {
 	parentMap := gt.ParentBranchMap()
@@ -110,6 +140,19 @@ func (gt *GitTown) ChildBranches(branch string) []string {
 	return result
 }
 
+// LinearDescendantBranches provides the descendants of the given branch that continue its lineage:
+// its only child, the only child of that child, and so on.
+// Stops at branches with several children because each of these children starts its own lineage.
+func (gt *GitTown) LinearDescendantBranches(branch string) []string {
+	result := []string{}
+	children := gt.ChildBranches(branch)
+	for len(children) == 1 {
+		result = append(result, children[0])
+		children = gt.ChildBranches(children[0])
+	}
+	return result
+}
+
 func (gt *GitTown) DeprecatedNewBranchPushFlagGlobal() string {
 	return gt.Storage.globalConfigCache[NewBranchPushFlagKey]
 }
@@ -123,6 +166,11 @@ func (gt *GitTown) GitAlias(aliasType AliasType) string {
 	return gt.Storage.GlobalConfigValue("alias." + string(aliasType))
 }
 
+// GitHubAPIURL provides the base URL of the GitHub API stored in the local or global Git Town configuration.
+func (gt *GitTown) GitHubAPIURL() string {
+	return gt.Storage.LocalOrGlobalConfigValue(GithubAPIURLKey)
+}
+
 // GitHubToken provides the content of the GitHub API token stored in the local or global Git Town configuration.
 func (gt *GitTown) GitHubToken() string {
 	return gt.Storage.LocalOrGlobalConfigValue(GithubTokenKey)
@@ -315,6 +363,11 @@ func (gt *GitTown) Reload() {
 	gt.Storage.Reload()
 }
 
+// RemoveAutoMergeProposal removes the automatically merged proposal entry for the given branch from the Git Town configuration.
+func (gt *GitTown) RemoveAutoMergeProposal(branch string) error {
+	return gt.Storage.RemoveLocalConfigValue("git-town-branch." + branch + ".auto-merge-proposal")
+}
+
 // RemoveFromPerennialBranches removes the given branch as a perennial branch.
 func (gt *GitTown) RemoveFromPerennialBranches(branch string) error {
 	return gt.SetPerennialBranches(stringslice.Remove(gt.PerennialBranches(), branch))
@@ -355,6 +408,19 @@ func (gt *GitTown) RemovePerennialBranchConfiguration() error {
 	return gt.Storage.RemoveLocalConfigValue(PerennialBranchesKey)
 }
 
+// RunstateLocation provides where Git Town stores the state of unfinished commands.
+func (gt *GitTown) RunstateLocation() (RunstateLocation, error) {
+	text := gt.Storage.LocalOrGlobalConfigValue(RunstateLocationKey)
+	return NewRunstateLocation(text)
+}
+
+// SetAutoMergeProposal marks the given branch as waiting for the code hosting service
+// to merge the proposal with the given number automatically.
+func (gt *GitTown) SetAutoMergeProposal(branch string, number int) error {
+	_, err := gt.Storage.SetLocalConfigValue("git-town-branch."+branch+".auto-merge-proposal", strconv.Itoa(number))
+	return err
+}
+
 // SetCodeHostingDriver sets the "github.code-hosting-driver" setting.
 func (gt *GitTown) SetCodeHostingDriver(value string) error {
 	gt.Storage.localConfigCache[CodeHostingDriverKey] = value
@@ -459,13 +525,25 @@ func (gt *GitTown) SetTestOrigin(value string) error {
 	return err
 }
 
+// ShipMessageTemplate provides the template for the commit messages of shipped branches.
+// An empty template means that Git Town doesn't pre-fill commit messages.
+func (gt *GitTown) ShipMessageTemplate() string {
+	return gt.Storage.LocalOrGlobalConfigValue(ShipMessageTemplateKey)
+}
+
+// ShipStrategy provides how "git ship" merges shipped branches into their parent branch.
+func (gt *GitTown) ShipStrategy() (ShipStrategy, error) {
+	text := gt.Storage.LocalOrGlobalConfigValue(ShipStrategyKey)
+	return NewShipStrategy(text)
+}
+
 // ShouldNewBranchPush indicates whether the current repository is configured to push
 // freshly created branches up to origin.
 func (gt *GitTown) ShouldNewBranchPush() (bool, error) {
 	oldLocalConfig := gt.DeprecatedNewBranchPushFlagLocal()
 	if oldLocalConfig != "" {
-		fmt.Printf("I found the deprecated local setting %q.\n", NewBranchPushFlagKey)
-		fmt.Printf("I am upgrading this setting to the new format %q.\n", PushNewBranchesKey)
+		cli.Printf("I found the deprecated local setting %q.\n", NewBranchPushFlagKey)
+		cli.Printf("I am upgrading this setting to the new format %q.\n", PushNewBranchesKey)
 		err := gt.Storage.RemoveLocalConfigValue(NewBranchPushFlagKey)
 		if err != nil {
 			return false, err
@@ -481,8 +559,8 @@ func (gt *GitTown) ShouldNewBranchPush() (bool, error) {
 	}
 	oldGlobalConfig := gt.DeprecatedNewBranchPushFlagGlobal()
 	if oldGlobalConfig != "" {
-		fmt.Printf("I found the deprecated global setting %q.\n", NewBranchPushFlagKey)
-		fmt.Printf("I am upgrading this setting to the new format %q.\n", PushNewBranchesKey)
+		cli.Printf("I found the deprecated global setting %q.\n", NewBranchPushFlagKey)
+		cli.Printf("I am upgrading this setting to the new format %q.\n", PushNewBranchesKey)
 		_, err := gt.Storage.RemoveGlobalConfigValue("git-town.new-branch-push-flag")
 		if err != nil {
 			return false, err
@@ -539,6 +617,13 @@ func (gt *GitTown) ShouldSyncUpstream() (bool, error) {
 	return cli.ParseBool(text)
 }
 
+// SquashAuthorMode provides how "git ship" determines the author of squash commits
+// for branches with multiple authors.
+func (gt *GitTown) SquashAuthorMode() (SquashAuthorMode, error) {
+	text := gt.Storage.LocalOrGlobalConfigValue(SquashAuthorModeKey)
+	return NewSquashAuthorMode(text)
+}
+
 func (gt *GitTown) SyncStrategy() (SyncStrategy, error) {
 	text := gt.Storage.LocalOrGlobalConfigValue(SyncStrategyKey)
 	return ToSyncStrategy(text)
@@ -549,6 +634,33 @@ func (gt *GitTown) SyncStrategyGlobal() (SyncStrategy, error) {
 	return ToSyncStrategy(setting)
 }
 
+// UpstreamURL provides the URL for the "upstream" remote,
+// or nil if the repository has no upstream remote.
+// Forks of a repository use the "upstream" remote to refer to the repository they were forked from.
+// Tests can stub this through the GIT_TOWN_UPSTREAM environment variable.
+func (gt *GitTown) UpstreamURL() *giturl.Parts {
+	text := os.Getenv("GIT_TOWN_UPSTREAM")
+	if text == "" {
+		res, err := gt.Storage.shell.Run("git", "remote", "get-url", UpstreamRemote)
+		if err != nil {
+			return nil
+		}
+		text = res.OutputSanitized()
+	}
+	if text == "" {
+		return nil
+	}
+	url := giturl.Parse(text)
+	if url == nil {
+		return nil
+	}
+	originOverride := gt.OriginOverride()
+	if originOverride != "" {
+		url.Host = originOverride
+	}
+	return url
+}
+
 // ValidateIsOnline asserts that Git Town is not in offline mode.
 func (gt *GitTown) ValidateIsOnline() error {
 	isOffline, err := gt.IsOffline()
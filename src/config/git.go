@@ -10,6 +10,10 @@ import (
 // Git manages configuration data stored in Git metadata.
 // Supports configuration in the local repo and the global Git configuration.
 type Git struct {
+	// dryRun indicates whether changes to the configuration only update the caches.
+	// It is shared between copies of this Git instance.
+	dryRun *bool
+
 	// globalConfigCache is a cache of the global Git configuration.
 	globalConfigCache map[string]string
 
@@ -51,12 +55,19 @@ func LoadGit(shell run.Shell, global bool) map[string]string {
 // NewConfiguration provides a Configuration instance reflecting the configuration values in the given directory.
 func NewGit(shell run.Shell) Git {
 	return Git{
+		dryRun:            new(bool),
 		localConfigCache:  LoadGit(shell, false),
 		globalConfigCache: LoadGit(shell, true),
 		shell:             shell,
 	}
 }
 
+// ActivateDryRun makes changes to the configuration only update the cached configuration,
+// so that the configuration reflects the simulated state without changing the Git configuration.
+func (g *Git) ActivateDryRun() {
+	*g.dryRun = true
+}
+
 // GlobalConfigValue provides the configuration value with the given key from the local Git configuration.
 func (g *Git) GlobalConfigValue(key string) string {
 	return g.globalConfigCache[key]
@@ -97,12 +108,18 @@ func (g *Git) Reload() {
 
 func (g *Git) RemoveGlobalConfigValue(key string) (*run.Result, error) {
 	delete(g.globalConfigCache, key)
+	if *g.dryRun {
+		return nil, nil //nolint:nilnil  // there is no result in dry-run mode
+	}
 	return g.shell.Run("git", "config", "--global", "--unset", key)
 }
 
 // removeLocalConfigurationValue deletes the configuration value with the given key from the local Git Town configuration.
 func (g *Git) RemoveLocalConfigValue(key string) error {
 	delete(g.localConfigCache, key)
+	if *g.dryRun {
+		return nil
+	}
 	_, err := g.shell.Run("git", "config", "--unset", key)
 	return err
 }
@@ -110,11 +127,17 @@ func (g *Git) RemoveLocalConfigValue(key string) error {
 // SetGlobalConfigValue sets the given configuration setting in the global Git configuration.
 func (g *Git) SetGlobalConfigValue(key, value string) (*run.Result, error) {
 	g.globalConfigCache[key] = value
+	if *g.dryRun {
+		return nil, nil //nolint:nilnil  // there is no result in dry-run mode
+	}
 	return g.shell.Run("git", "config", "--global", key, value)
 }
 
 // SetLocalConfigValue sets the local configuration with the given key to the given value.
 func (g *Git) SetLocalConfigValue(key, value string) (*run.Result, error) {
 	g.localConfigCache[key] = value
+	if *g.dryRun {
+		return nil, nil //nolint:nilnil  // there is no result in dry-run mode
+	}
 	return g.shell.Run("git", "config", key, value)
 }
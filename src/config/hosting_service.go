@@ -9,11 +9,13 @@ import (
 type HostingService string
 
 const (
-	HostingServiceBitbucket HostingService = "bitbucket"
-	HostingServiceGitHub    HostingService = "github"
-	HostingServiceGitLab    HostingService = "gitlab"
-	HostingServiceGitea     HostingService = "gitea"
-	HostingServiceNone      HostingService = ""
+	HostingServiceAzure           HostingService = "azure"
+	HostingServiceBitbucket       HostingService = "bitbucket"
+	HostingServiceBitbucketServer HostingService = "bitbucket-server"
+	HostingServiceGitHub          HostingService = "github"
+	HostingServiceGitLab          HostingService = "gitlab"
+	HostingServiceGitea           HostingService = "gitea"
+	HostingServiceNone            HostingService = ""
 )
 
 // NewHostingService provides the HostingService enum matching the given text.
@@ -31,7 +33,9 @@ func NewHostingService(text string) (HostingService, error) {
 func hostingServices() []HostingService {
 	return []HostingService{
 		HostingServiceNone,
+		HostingServiceAzure,
 		HostingServiceBitbucket,
+		HostingServiceBitbucketServer,
 		HostingServiceGitHub,
 		HostingServiceGitLab,
 		HostingServiceGitea,
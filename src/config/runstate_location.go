@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RunstateLocation defines legal values for the "runstate-location" configuration setting.
+type RunstateLocation string
+
+const (
+	// RunstateLocationConfigDir stores the run state in the user's configuration directory,
+	// which works for read-only checkouts.
+	RunstateLocationConfigDir RunstateLocation = "config-dir"
+	// RunstateLocationGitDir stores the run state in the Git directory of the current worktree.
+	RunstateLocationGitDir RunstateLocation = "git-dir"
+)
+
+func NewRunstateLocation(text string) (RunstateLocation, error) {
+	switch strings.ToLower(text) {
+	case "git-dir", "":
+		return RunstateLocationGitDir, nil
+	case "config-dir":
+		return RunstateLocationConfigDir, nil
+	default:
+		return RunstateLocationGitDir, fmt.Errorf("unknown run state location: %q", text)
+	}
+}
+
+func (rl RunstateLocation) String() string {
+	return string(rl)
+}
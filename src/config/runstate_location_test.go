@@ -0,0 +1,38 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRunstateLocation(t *testing.T) {
+	t.Parallel()
+	t.Run("valid content", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]config.RunstateLocation{
+			"git-dir":    config.RunstateLocationGitDir,
+			"config-dir": config.RunstateLocationConfigDir,
+			"Config-Dir": config.RunstateLocationConfigDir,
+		}
+		for give, want := range tests {
+			have, err := config.NewRunstateLocation(give)
+			assert.Nil(t, err)
+			assert.Equal(t, want, have)
+		}
+	})
+
+	t.Run("defaults to the Git directory", func(t *testing.T) {
+		t.Parallel()
+		have, err := config.NewRunstateLocation("")
+		assert.Nil(t, err)
+		assert.Equal(t, config.RunstateLocationGitDir, have)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		_, err := config.NewRunstateLocation("zonk")
+		assert.Error(t, err)
+	})
+}
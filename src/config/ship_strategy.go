@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShipStrategy defines legal values for the "ship-strategy" configuration setting.
+type ShipStrategy string
+
+const (
+	// ShipStrategyFastForward fast-forwards the parent branch to the shipped branch.
+	ShipStrategyFastForward ShipStrategy = "fast-forward"
+	// ShipStrategyMerge merges the shipped branch into the parent branch with a merge commit.
+	ShipStrategyMerge ShipStrategy = "merge"
+	// ShipStrategyRebase rebases the commits of the shipped branch onto the parent branch
+	// and fast-forwards the parent branch to them.
+	ShipStrategyRebase ShipStrategy = "rebase"
+	// ShipStrategySquash combines the changes of the shipped branch into a single commit on the parent branch.
+	ShipStrategySquash ShipStrategy = "squash"
+)
+
+func NewShipStrategy(text string) (ShipStrategy, error) {
+	switch strings.ToLower(text) {
+	case "squash", "":
+		return ShipStrategySquash, nil
+	case "merge":
+		return ShipStrategyMerge, nil
+	case "rebase":
+		return ShipStrategyRebase, nil
+	case "fast-forward":
+		return ShipStrategyFastForward, nil
+	default:
+		return ShipStrategySquash, fmt.Errorf("unknown ship strategy: %q", text)
+	}
+}
+
+// CreatesCommit indicates whether shipping with this strategy creates a new commit
+// on the parent branch, which needs a commit message.
+func (ss ShipStrategy) CreatesCommit() bool {
+	return ss == ShipStrategySquash || ss == ShipStrategyMerge
+}
+
+func (ss ShipStrategy) String() string {
+	return string(ss)
+}
@@ -0,0 +1,53 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShipStrategy(t *testing.T) {
+	t.Parallel()
+	t.Run("valid content", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]config.ShipStrategy{
+			"squash":       config.ShipStrategySquash,
+			"merge":        config.ShipStrategyMerge,
+			"rebase":       config.ShipStrategyRebase,
+			"fast-forward": config.ShipStrategyFastForward,
+			"Merge":        config.ShipStrategyMerge,
+		}
+		for give, want := range tests {
+			have, err := config.NewShipStrategy(give)
+			assert.Nil(t, err)
+			assert.Equal(t, want, have)
+		}
+	})
+
+	t.Run("defaults to squash", func(t *testing.T) {
+		t.Parallel()
+		have, err := config.NewShipStrategy("")
+		assert.Nil(t, err)
+		assert.Equal(t, config.ShipStrategySquash, have)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		_, err := config.NewShipStrategy("zonk")
+		assert.Error(t, err)
+	})
+}
+
+func TestShipStrategyCreatesCommit(t *testing.T) {
+	t.Parallel()
+	tests := map[config.ShipStrategy]bool{
+		config.ShipStrategySquash:      true,
+		config.ShipStrategyMerge:       true,
+		config.ShipStrategyRebase:      false,
+		config.ShipStrategyFastForward: false,
+	}
+	for give, want := range tests {
+		assert.Equal(t, want, give.CreatesCommit(), give)
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SquashAuthorMode defines legal values for the "squash-author-mode" configuration setting.
+type SquashAuthorMode string
+
+const (
+	// SquashAuthorModeBranchOwner makes the person who made the first commit on a branch
+	// the author of its squash commit and credits everybody else via "Co-authored-by" trailers.
+	SquashAuthorModeBranchOwner SquashAuthorMode = "branch-owner"
+	// SquashAuthorModeChoose asks which of the authors of a branch to use as the author of its squash commit.
+	SquashAuthorModeChoose SquashAuthorMode = "choose"
+	// SquashAuthorModeCurrentUser makes the current Git user the author of squash commits
+	// and credits everybody else via "Co-authored-by" trailers.
+	SquashAuthorModeCurrentUser SquashAuthorMode = "current-user"
+)
+
+func NewSquashAuthorMode(text string) (SquashAuthorMode, error) {
+	switch strings.ToLower(text) {
+	case "choose", "":
+		return SquashAuthorModeChoose, nil
+	case "branch-owner":
+		return SquashAuthorModeBranchOwner, nil
+	case "current-user":
+		return SquashAuthorModeCurrentUser, nil
+	default:
+		return SquashAuthorModeChoose, fmt.Errorf("unknown squash author mode: %q", text)
+	}
+}
+
+// AddsCoAuthors indicates whether squash commits in this mode credit the other authors of the branch
+// via "Co-authored-by" trailers.
+func (sam SquashAuthorMode) AddsCoAuthors() bool {
+	return sam == SquashAuthorModeBranchOwner || sam == SquashAuthorModeCurrentUser
+}
+
+func (sam SquashAuthorMode) String() string {
+	return string(sam)
+}
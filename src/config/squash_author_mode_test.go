@@ -0,0 +1,39 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSquashAuthorMode(t *testing.T) {
+	t.Parallel()
+	t.Run("valid content", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]config.SquashAuthorMode{
+			"choose":       config.SquashAuthorModeChoose,
+			"branch-owner": config.SquashAuthorModeBranchOwner,
+			"current-user": config.SquashAuthorModeCurrentUser,
+			"Current-User": config.SquashAuthorModeCurrentUser,
+		}
+		for give, want := range tests {
+			have, err := config.NewSquashAuthorMode(give)
+			assert.Nil(t, err)
+			assert.Equal(t, want, have)
+		}
+	})
+
+	t.Run("defaults to choosing the author", func(t *testing.T) {
+		t.Parallel()
+		have, err := config.NewSquashAuthorMode("")
+		assert.Nil(t, err)
+		assert.Equal(t, config.SquashAuthorModeChoose, have)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		t.Parallel()
+		_, err := config.NewSquashAuthorMode("zonk")
+		assert.Error(t, err)
+	})
+}
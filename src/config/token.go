@@ -0,0 +1,117 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Token is an API token for a code hosting service together with where Git Town found it.
+type Token struct {
+	Source string // describes where the token comes from, empty if Git Town found no token
+	Value  string
+}
+
+// Masked provides the value of this token with most of it hidden, so that it's safe to display.
+// Returns an empty string if there is no token.
+func (t Token) Masked() string {
+	if t.Value == "" {
+		return ""
+	}
+	const mask = "********"
+	if len(t.Value) < 12 {
+		return mask
+	}
+	return mask + t.Value[len(t.Value)-4:]
+}
+
+// HostTokenKey provides the Git configuration key for the API token
+// for the given code hosting service at the given hostname.
+func HostTokenKey(service HostingService, hostname string) string {
+	return "git-town-host." + hostname + "." + strings.TrimPrefix(tokenLookupFor(service).key, "git-town.")
+}
+
+// APIToken provides the API token for the given code hosting service running at the given hostname.
+// It uses the first token it finds in:
+// - the Git Town configuration for the given hostname
+// - the Git Town configuration for the code hosting service
+// - the environment variable that the tools of the code hosting service use
+// - the Git credential helpers for HTTPS connections to the given hostname
+// An empty hostname skips the sources for a specific hostname.
+func (gt *GitTown) APIToken(service HostingService, hostname string) Token {
+	lookup := tokenLookupFor(service)
+	if lookup.key == "" {
+		return Token{Source: "", Value: ""}
+	}
+	if hostname != "" {
+		hostKey := HostTokenKey(service, hostname)
+		if value := gt.Storage.LocalOrGlobalConfigValue(hostKey); value != "" {
+			return Token{Source: "Git configuration " + hostKey, Value: value}
+		}
+	}
+	if value := gt.Storage.LocalOrGlobalConfigValue(lookup.key); value != "" {
+		return Token{Source: "Git configuration " + lookup.key, Value: value}
+	}
+	if lookup.envVar != "" {
+		if value := os.Getenv(lookup.envVar); value != "" {
+			return Token{Source: "environment variable " + lookup.envVar, Value: value}
+		}
+	}
+	if lookup.useCredentialHelper && hostname != "" {
+		if value := gt.credentialHelperPassword(hostname); value != "" {
+			return Token{Source: "Git credential helper for " + hostname, Value: value}
+		}
+	}
+	return Token{Source: "", Value: ""}
+}
+
+// credentialHelperPassword provides the password that the Git credential helpers provide for HTTPS connections to the given host.
+// Caches its result so can be called repeatedly.
+func (gt *GitTown) credentialHelperPassword(hostname string) string {
+	cached, has := gt.credentialCache[hostname]
+	if has {
+		return cached
+	}
+	password := ""
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", hostname))
+	// fail instead of asking the user for credentials that no credential helper knows
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	output, err := cmd.Output()
+	if err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			if strings.HasPrefix(line, "password=") {
+				password = strings.TrimSpace(strings.TrimPrefix(line, "password="))
+			}
+		}
+	}
+	gt.credentialCache[hostname] = password
+	return password
+}
+
+// tokenLookup describes where to look for the API token of a code hosting service.
+type tokenLookup struct {
+	envVar              string // the environment variable that the tools of the code hosting service use for the token
+	key                 string // the Git Town configuration key for the token
+	useCredentialHelper bool   // whether the password for HTTPS connections works as an API token
+}
+
+// tokenLookupFor provides where to look for the API token of the given code hosting service.
+func tokenLookupFor(service HostingService) tokenLookup {
+	switch service {
+	case HostingServiceAzure:
+		return tokenLookup{envVar: "", key: AzureTokenKey, useCredentialHelper: false}
+	case HostingServiceBitbucket, HostingServiceBitbucketServer:
+		// Bitbucket needs the username together with the app password
+		return tokenLookup{envVar: "", key: BitbucketTokenKey, useCredentialHelper: false}
+	case HostingServiceGitHub:
+		return tokenLookup{envVar: "GITHUB_TOKEN", key: GithubTokenKey, useCredentialHelper: true}
+	case HostingServiceGitLab:
+		return tokenLookup{envVar: "GITLAB_TOKEN", key: GitlabTokenKey, useCredentialHelper: true}
+	case HostingServiceGitea:
+		return tokenLookup{envVar: "", key: GiteaTokenKey, useCredentialHelper: true}
+	case HostingServiceNone:
+	}
+	return tokenLookup{envVar: "", key: "", useCredentialHelper: false}
+}
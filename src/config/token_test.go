@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	t.Parallel()
+	t.Run(".Masked()", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]string{
+			"":                     "",
+			"short":                "********",
+			"ghp_1234567890abcdef": "********cdef",
+		}
+		for give, want := range tests {
+			token := config.Token{Source: "test", Value: give}
+			assert.Equal(t, want, token.Masked())
+		}
+	})
+}
+
+func TestHostTokenKey(t *testing.T) {
+	t.Parallel()
+	have := config.HostTokenKey(config.HostingServiceGitLab, "gitlab.example.com")
+	assert.Equal(t, "git-town-host.gitlab.example.com.gitlab-token", have)
+}
+
+func TestAPIToken(t *testing.T) {
+	t.Parallel()
+	t.Run("prefers the token for the hostname", func(t *testing.T) {
+		t.Parallel()
+		repo := test.CreateTestGitTownRepo(t)
+		_, err := repo.Config.Storage.SetLocalConfigValue(config.GitlabTokenKey, "global")
+		assert.NoError(t, err)
+		_, err = repo.Config.Storage.SetLocalConfigValue("git-town-host.gitlab.example.com.gitlab-token", "host")
+		assert.NoError(t, err)
+		have := repo.Config.APIToken(config.HostingServiceGitLab, "gitlab.example.com")
+		want := config.Token{Source: "Git configuration git-town-host.gitlab.example.com.gitlab-token", Value: "host"}
+		assert.Equal(t, want, have)
+		have = repo.Config.APIToken(config.HostingServiceGitLab, "other.example.com")
+		want = config.Token{Source: "Git configuration git-town.gitlab-token", Value: "global"}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("unknown hosting service", func(t *testing.T) {
+		t.Parallel()
+		repo := test.CreateTestGitTownRepo(t)
+		have := repo.Config.APIToken(config.HostingServiceNone, "example.com")
+		assert.Equal(t, config.Token{Source: "", Value: ""}, have)
+	})
+}
@@ -9,17 +9,22 @@ import (
 type askForBranchOptions struct {
 	branches      []string
 	defaultBranch string
+	input         string // describes how to provide the answer when Git Town runs non-interactively
 	prompt        string
 }
 
 type askForBranchesOptions struct {
 	branches        []string
 	defaultBranches []string
+	input           string // describes how to provide the answer when Git Town runs non-interactively
 	prompt          string
 }
 
 func askForBranch(opts askForBranchOptions) (string, error) {
 	result := ""
+	if err := ensureCanAsk(opts.prompt, opts.branches, opts.input); err != nil {
+		return result, err
+	}
 	prompt := &survey.Select{
 		Message: opts.prompt,
 		Options: opts.branches,
@@ -34,6 +39,9 @@ func askForBranch(opts askForBranchOptions) (string, error) {
 
 func askForBranches(opts askForBranchesOptions) ([]string, error) {
 	result := []string{}
+	if err := ensureCanAsk(opts.prompt, opts.branches, opts.input); err != nil {
+		return result, err
+	}
 	prompt := &survey.MultiSelect{
 		Message: opts.prompt,
 		Options: opts.branches,
@@ -5,14 +5,15 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
 )
 
 // EnsureIsConfigured has the user to confgure the main branch and perennial branches if needed.
 func EnsureIsConfigured(repo *git.ProdRepo) error {
 	if repo.Config.MainBranch() == "" {
-		fmt.Println("Git Town needs to be configured")
-		fmt.Println()
+		cli.Println("Git Town needs to be configured")
+		cli.Println()
 		err := ConfigureMainBranch(repo)
 		if err != nil {
 			return err
@@ -30,8 +31,9 @@ func ConfigureMainBranch(repo *git.ProdRepo) error {
 	}
 	newMainBranch, err := askForBranch(askForBranchOptions{
 		branches:      localBranches,
-		prompt:        mainBranchPrompt(repo),
 		defaultBranch: repo.Config.MainBranch(),
+		input:         `the main branch via "git town config main-branch <branch>"`,
+		prompt:        mainBranchPrompt(repo),
 	})
 	if err != nil {
 		return err
@@ -50,8 +52,9 @@ func ConfigurePerennialBranches(repo *git.ProdRepo) error {
 	}
 	newPerennialBranches, err := askForBranches(askForBranchesOptions{
 		branches:        branches,
-		prompt:          perennialBranchesPrompt(repo),
 		defaultBranches: repo.Config.PerennialBranches(),
+		input:           `the perennial branches via "git config git-town.perennial-branch-names <branches>"`,
+		prompt:          perennialBranchesPrompt(repo),
 	})
 	if err != nil {
 		return err
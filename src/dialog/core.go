@@ -2,8 +2,10 @@
 package dialog
 
 import (
+	"fmt"
 	"runtime"
 
+	"github.com/git-town/git-town/v7/src/cli"
 	surveyCore "gopkg.in/AlecAivazis/survey.v1/core"
 )
 
@@ -15,3 +17,38 @@ func Initialize() {
 		surveyCore.UnmarkedOptionIcon = "[ ]"
 	}
 }
+
+// Answers contains answers to dialogs that the user provides upfront,
+// so that Git Town can run without asking questions, for example on CI servers.
+type Answers struct {
+	NonInteractive bool   // whether to fail instead of asking questions that have no answer here
+	OnUnfinished   string // how to handle an unfinished run state, one of the ResponseType values
+	Parent         string // the parent for branches whose parent is unknown
+	SquashAuthor   string // the author of squash commits for branches with multiple authors
+}
+
+// answers contains the answers that the dialogs use instead of asking the user.
+var answers Answers //nolint:gochecknoglobals
+
+// UseAnswers makes all dialogs use the given answers instead of asking the user.
+func UseAnswers(newAnswers Answers) {
+	answers = newAnswers
+}
+
+// IsInteractive indicates whether Git Town can ask the user for input,
+// for example by opening the editor for a commit message.
+func IsInteractive() bool {
+	return !answers.NonInteractive && !cli.IsJSONOutput()
+}
+
+// ensureCanAsk signals an error if Git Town cannot ask the user the given question
+// because it runs non-interactively or provides JSON output.
+// The given options are the answers that the user could choose from,
+// the given input describes how the user can provide the answer upfront.
+func ensureCanAsk(prompt string, options []string, input string) error {
+	if IsInteractive() {
+		return nil
+	}
+	cli.EmitEvent("prompt-unanswered", cli.Event{"prompt": prompt, "options": options, "input": input})
+	return fmt.Errorf("missing input: please provide %s", input)
+}
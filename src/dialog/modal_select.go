@@ -17,6 +17,9 @@ func ModalSelect(entries ModalEntries, initialValue string) (*string, error) {
 	if initialPos == nil {
 		return nil, fmt.Errorf("given initial value %q not in given entries", initialValue)
 	}
+	if err := ensureCanAsk("Please select an entry:", entries.Values(), "the selection via command-line arguments"); err != nil {
+		return nil, err
+	}
 	input := modalSelect{
 		entries:       entries,
 		activeCursor:  "> ",
@@ -139,6 +142,15 @@ func (mes ModalEntries) IndexOfValue(value string) *int {
 	return nil
 }
 
+// Values provides the values of all entries.
+func (mes ModalEntries) Values() []string {
+	result := make([]string, len(mes))
+	for e, entry := range mes {
+		result[e] = entry.Value
+	}
+	return result
+}
+
 // modalSelectStatus represents the different states that a modalSelect instance can be in.
 type modalSelectStatus int
 
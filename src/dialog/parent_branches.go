@@ -5,6 +5,7 @@ import (
 
 	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/stringslice"
 )
 
 type ParentBranches struct {
@@ -24,7 +25,7 @@ func (pbd *ParentBranches) EnsureKnowsParentBranches(branches []string, repo *gi
 			return err
 		}
 		if pbd.parentBranchHeaderShown {
-			fmt.Println()
+			cli.Println()
 		}
 	}
 	return nil
@@ -69,10 +70,14 @@ func (pbd *ParentBranches) AskForBranchParent(branch, defaultBranch string, repo
 		return "", err
 	}
 	filteredChoices := filterOutSelfAndDescendants(branch, choices, repo)
+	if stringslice.Contains(filteredChoices, answers.Parent) {
+		return answers.Parent, nil
+	}
 	return askForBranch(askForBranchOptions{
 		branches:      append([]string{perennialBranchOption}, filteredChoices...),
-		prompt:        fmt.Sprintf(parentBranchPromptTemplate, branch),
 		defaultBranch: defaultBranch,
+		input:         fmt.Sprintf(`the parent branch of %q via "--parent"`, branch),
+		prompt:        fmt.Sprintf(parentBranchPromptTemplate, branch),
 	})
 }
 
@@ -0,0 +1,35 @@
+package dialog
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	survey "gopkg.in/AlecAivazis/survey.v1"
+)
+
+// ConfirmShipDespiteProblems asks the user whether to ship the given branch
+// even though its proposal has the given problems, like failing CI or missing approvals.
+func ConfirmShipDespiteProblems(branch string, problems []string) (bool, error) {
+	cli.Printf(shipProblemsHeaderTemplate, branch)
+	cli.Println()
+	for _, problem := range problems {
+		cli.Println("- " + problem)
+	}
+	cli.Println()
+	prompt := fmt.Sprintf(shipProblemsPromptTemplate, branch)
+	if err := ensureCanAsk(prompt, []string{"yes", "no"}, `the "--ignore-checks" flag to ship anyway`); err != nil {
+		return false, err
+	}
+	result := false
+	err := survey.AskOne(&survey.Confirm{Message: prompt, Default: false}, &result, nil)
+	if err != nil {
+		return false, fmt.Errorf("cannot read user answer from CLI: %w", err)
+	}
+	return result, nil
+}
+
+// Helpers
+
+const shipProblemsHeaderTemplate = "The proposal for the %q branch isn't ready to ship:"
+
+const shipProblemsPromptTemplate = "Ship the %q branch anyway?"
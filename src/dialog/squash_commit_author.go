@@ -11,7 +11,8 @@ import (
 )
 
 // DetermineSquashCommitAuthor gets the author of the supplied branch.
-// If the branch has more than one author, the author is queried from the user.
+// If the branch has more than one author, the author is queried from the user
+// unless it was provided upfront.
 func DetermineSquashCommitAuthor(branch string, repo *git.ProdRepo) (string, error) {
 	authors, err := loadBranchAuthors(branch, repo)
 	if err != nil {
@@ -20,8 +21,11 @@ func DetermineSquashCommitAuthor(branch string, repo *git.ProdRepo) (string, err
 	if len(authors) == 1 {
 		return authors[0], nil
 	}
+	if answers.SquashAuthor != "" {
+		return answers.SquashAuthor, nil
+	}
 	cli.Printf(squashCommitAuthorHeaderTemplate, branch)
-	fmt.Println()
+	cli.Println()
 	return askForAuthor(authors)
 }
 
@@ -29,10 +33,15 @@ func DetermineSquashCommitAuthor(branch string, repo *git.ProdRepo) (string, err
 
 const squashCommitAuthorHeaderTemplate = "Multiple people authored the %q branch."
 
+const squashCommitAuthorPrompt = "Please choose an author for the squash commit:"
+
 func askForAuthor(authors []string) (string, error) {
 	result := ""
+	if err := ensureCanAsk(squashCommitAuthorPrompt, authors, `the author via "--squash-author"`); err != nil {
+		return result, err
+	}
 	prompt := &survey.Select{
-		Message: "Please choose an author for the squash commit:",
+		Message: squashCommitAuthorPrompt,
 		Options: authors,
 	}
 	err := survey.AskOne(prompt, &result, nil)
@@ -21,8 +21,21 @@ const (
 	ResponseTypeSkip = "skip"
 )
 
-// AskHowToHandleUnfinishedRunState prompts the user for how to handle the unfinished run state.
+// UnfinishedRunStateResponses provides the responses for how to handle an unfinished run state
+// that the user can provide upfront.
+func UnfinishedRunStateResponses() []string {
+	return []string{ResponseTypeContinue, ResponseTypeAbort, ResponseTypeDiscard, ResponseTypeSkip}
+}
+
+// AskHowToHandleUnfinishedRunState prompts the user for how to handle the unfinished run state
+// unless the response was provided upfront.
 func AskHowToHandleUnfinishedRunState(command, endBranch string, endTime time.Time, canSkip bool) (string, error) {
+	if answers.OnUnfinished != "" {
+		if answers.OnUnfinished == ResponseTypeSkip && !canSkip {
+			return "", fmt.Errorf("the unfinished `%s` command cannot be continued by skipping the current branch", command)
+		}
+		return answers.OnUnfinished, nil
+	}
 	formattedOptions := map[string]string{
 		ResponseTypeAbort:    fmt.Sprintf("Abort the `%s` command", command),
 		ResponseTypeContinue: fmt.Sprintf("Restart the `%s` command after having resolved conflicts", command),
@@ -38,8 +51,12 @@ func AskHowToHandleUnfinishedRunState(command, endBranch string, endTime time.Ti
 		options = append(options, formattedOptions[ResponseTypeSkip])
 	}
 	options = append(options, formattedOptions[ResponseTypeAbort], formattedOptions[ResponseTypeDiscard])
+	message := fmt.Sprintf("You have an unfinished `%s` command that ended on the `%s` branch %s. Please choose how to proceed", command, endBranch, humanize.Time(endTime))
+	if err := ensureCanAsk(message, options, `how to proceed via "--on-unfinished"`); err != nil {
+		return "", err
+	}
 	prompt := &survey.Select{
-		Message: fmt.Sprintf("You have an unfinished `%s` command that ended on the `%s` branch %s. Please choose how to proceed", command, endBranch, humanize.Time(endTime)),
+		Message: message,
 		Options: options,
 		Default: formattedOptions[ResponseTypeQuit],
 	}
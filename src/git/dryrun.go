@@ -1,16 +1,35 @@
 package git
 
+import (
+	"sort"
+
+	"github.com/git-town/git-town/v7/src/stringslice"
+)
+
 // DryRun implements the dry-run feature.
+// It tracks the simulated state of the repository
+// so that queries return answers consistent with the commands that would have run.
 // The zero value is a non-activated DryRun.
 type DryRun struct {
-	active        bool   `exhaustruct:"optional"`
-	currentBranch string `exhaustruct:"optional"`
+	active bool `exhaustruct:"optional"`
+	// createdBranches contains the branches created during the dry run and the branch or SHA they were created from
+	createdBranches map[string]string `exhaustruct:"optional"`
+	currentBranch   string            `exhaustruct:"optional"`
+	// deletedBranches contains the local branches deleted during the dry run
+	deletedBranches []string `exhaustruct:"optional"`
+	// pushedBranches contains the branches pushed to a new tracking branch during the dry run
+	pushedBranches []string `exhaustruct:"optional"`
+	// stashSize is the number of changes that the dry run has added to the stash
+	stashSize int `exhaustruct:"optional"`
 }
 
 // Activate enables dry-run.
 func (dr *DryRun) Activate(currentBranch string) {
 	dr.active = true
 	dr.currentBranch = currentBranch
+	dr.createdBranches = map[string]string{}
+	dr.deletedBranches = []string{}
+	dr.pushedBranches = []string{}
 }
 
 // ChangeBranch allows code to indicate to DryRun that the current branch has changed.
@@ -18,12 +37,98 @@ func (dr *DryRun) ChangeBranch(name string) {
 	dr.currentBranch = name
 }
 
+// CreateBranch allows code to indicate to DryRun that a branch with the given name
+// was created from the given branch or SHA.
+func (dr *DryRun) CreateBranch(name, startingPoint string) {
+	dr.createdBranches[name] = startingPoint
+	if stringslice.Contains(dr.deletedBranches, name) {
+		dr.deletedBranches = stringslice.Remove(dr.deletedBranches, name)
+	}
+}
+
+// CreatedBranch indicates whether the branch with the given name was created during the dry run.
+func (dr *DryRun) CreatedBranch(name string) bool {
+	_, created := dr.createdBranches[name]
+	return created
+}
+
 // CurrentBranch provides the name of the current branch.
 func (dr *DryRun) CurrentBranch() string {
 	return dr.currentBranch
 }
 
+// DeleteBranch allows code to indicate to DryRun that the local branch with the given name was deleted.
+func (dr *DryRun) DeleteBranch(name string) {
+	delete(dr.createdBranches, name)
+	if !stringslice.Contains(dr.deletedBranches, name) {
+		dr.deletedBranches = append(dr.deletedBranches, name)
+	}
+}
+
 // IsActive indicates whether dry-run is active.
 func (dr *DryRun) IsActive() bool {
 	return dr.active
 }
+
+// LocalBranches provides the local branches that exist in the simulated state,
+// given the local branches that exist in the actual repository.
+func (dr *DryRun) LocalBranches(actualBranches []string) []string {
+	result := []string{}
+	for _, branch := range actualBranches {
+		if !stringslice.Contains(dr.deletedBranches, branch) {
+			result = append(result, branch)
+		}
+	}
+	for branch := range dr.createdBranches {
+		if !stringslice.Contains(result, branch) {
+			result = append(result, branch)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// PopStash allows code to indicate to DryRun that the most recently stashed changes were restored.
+func (dr *DryRun) PopStash() {
+	if dr.stashSize > 0 {
+		dr.stashSize--
+	}
+}
+
+// PushBranch allows code to indicate to DryRun that the branch with the given name now has a tracking branch.
+func (dr *DryRun) PushBranch(name string) {
+	if !stringslice.Contains(dr.pushedBranches, name) {
+		dr.pushedBranches = append(dr.pushedBranches, name)
+	}
+}
+
+// PushedBranch indicates whether the branch with the given name got a tracking branch during the dry run.
+func (dr *DryRun) PushedBranch(name string) bool {
+	return stringslice.Contains(dr.pushedBranches, name)
+}
+
+// ResolveBranch provides the name of the existing branch or SHA that the given branch
+// created during the dry run points to.
+// Returns the given name for branches that exist in the actual repository.
+func (dr *DryRun) ResolveBranch(name string) string {
+	if name == "HEAD" {
+		name = dr.currentBranch
+	}
+	for {
+		startingPoint, created := dr.createdBranches[name]
+		if !created {
+			return name
+		}
+		name = startingPoint
+	}
+}
+
+// Stash allows code to indicate to DryRun that the open changes were added to the stash.
+func (dr *DryRun) Stash() {
+	dr.stashSize++
+}
+
+// StashSize provides the number of changes that the dry run added to the stash.
+func (dr *DryRun) StashSize() int {
+	return dr.stashSize
+}
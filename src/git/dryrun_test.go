@@ -0,0 +1,43 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+	t.Run("LocalBranches", func(t *testing.T) {
+		t.Parallel()
+		dryRun := git.DryRun{}
+		dryRun.Activate("main")
+		dryRun.CreateBranch("new", "main")
+		dryRun.DeleteBranch("old")
+		have := dryRun.LocalBranches([]string{"main", "old", "other"})
+		assert.Equal(t, []string{"main", "new", "other"}, have)
+	})
+
+	t.Run("ResolveBranch", func(t *testing.T) {
+		t.Parallel()
+		dryRun := git.DryRun{}
+		dryRun.Activate("main")
+		dryRun.CreateBranch("parent", "main")
+		dryRun.CreateBranch("child", "parent")
+		dryRun.ChangeBranch("child")
+		assert.Equal(t, "main", dryRun.ResolveBranch("child"))
+		assert.Equal(t, "main", dryRun.ResolveBranch("HEAD"))
+		assert.Equal(t, "other", dryRun.ResolveBranch("other"))
+	})
+
+	t.Run("stash", func(t *testing.T) {
+		t.Parallel()
+		dryRun := git.DryRun{}
+		dryRun.Activate("main")
+		dryRun.Stash()
+		assert.Equal(t, 1, dryRun.StashSize())
+		dryRun.PopStash()
+		assert.Equal(t, 0, dryRun.StashSize())
+	})
+}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/run"
 	"github.com/kballard/go-shellquote"
 )
@@ -33,23 +35,18 @@ func (shell LoggingShell) WorkingDir() string {
 
 // Run runs the given command in this ShellRunner's directory.
 func (shell LoggingShell) Run(cmd string, args ...string) (*run.Result, error) {
+	if cli.IsJSONOutput() {
+		return nil, shell.runAndEmitEvent(cmd, args...)
+	}
 	err := shell.PrintCommand(cmd, args...)
 	if err != nil {
 		return nil, err
 	}
 	if shell.dryRun.IsActive() {
-		if len(args) == 2 && cmd == "git" && args[0] == "checkout" {
-			shell.dryRun.ChangeBranch(args[1])
-		}
+		shell.simulate(cmd, args...)
 		return nil, nil //nolint:nilnil  // Can return nil result if dryRun is enabled
 	}
-	// Windows commands run inside CMD
-	// because opening browsers is done via "start"
-	if runtime.GOOS == "windows" {
-		args = append([]string{"/C", cmd}, args...)
-		cmd = "cmd"
-	}
-	subProcess := exec.Command(cmd, args...) // #nosec
+	subProcess := newSubProcess(cmd, args...)
 	subProcess.Stderr = os.Stderr
 	subProcess.Stdin = os.Stdin
 	subProcess.Stdout = os.Stdout
@@ -96,15 +93,15 @@ func (shell LoggingShell) PrintCommand(cmd string, args ...string) error {
 		}
 		header += part
 	}
-	if cmd == "git" && shell.silentRunner.IsRepository() {
-		currentBranch, err := shell.silentRunner.CurrentBranch()
-		if err != nil {
-			return err
-		}
+	currentBranch, err := shell.branchFor(cmd)
+	if err != nil {
+		return err
+	}
+	if currentBranch != "" {
 		header = fmt.Sprintf("[%s] %s", currentBranch, header)
 	}
 	fmt.Println()
-	_, err := color.New(color.Bold).Println(header)
+	_, err = color.New(color.Bold).Println(header)
 	if err != nil {
 		fmt.Println(header)
 	}
@@ -113,7 +110,95 @@ func (shell LoggingShell) PrintCommand(cmd string, args ...string) error {
 
 // PrintCommand prints the given command-line operation on the console.
 func (shell LoggingShell) PrintCommandAndOutput(result *run.Result) error {
+	if cli.IsJSONOutput() {
+		event, err := shell.commandEvent(result.Command(), result.Args()...)
+		event["exitCode"] = result.ExitCode()
+		event["output"] = result.Output()
+		cli.EmitEvent("subprocess", event)
+		return err
+	}
 	err := shell.PrintCommand(result.Command(), result.Args()...)
 	fmt.Println(result.Output())
 	return err
 }
+
+// branchFor provides the branch on which the given command runs, or an empty string if that doesn't apply.
+func (shell LoggingShell) branchFor(cmd string) (string, error) {
+	if cmd != "git" || !shell.silentRunner.IsRepository() {
+		return "", nil
+	}
+	return shell.silentRunner.CurrentBranch()
+}
+
+// commandEvent provides the machine-readable description of the given command-line operation.
+func (shell LoggingShell) commandEvent(cmd string, args ...string) (cli.Event, error) {
+	event := cli.Event{"executable": cmd, "args": args}
+	currentBranch, err := shell.branchFor(cmd)
+	if currentBranch != "" {
+		event["branch"] = currentBranch
+	}
+	return event, err
+}
+
+// runAndEmitEvent runs the given command and reports it together with its exit code and output
+// as an event in the machine-readable output.
+func (shell LoggingShell) runAndEmitEvent(cmd string, args ...string) error {
+	event, err := shell.commandEvent(cmd, args...)
+	if err != nil {
+		return err
+	}
+	if shell.dryRun.IsActive() {
+		shell.simulate(cmd, args...)
+		event["dryRun"] = true
+		cli.EmitEvent("subprocess", event)
+		return nil
+	}
+	var output bytes.Buffer
+	subProcess := newSubProcess(cmd, args...)
+	subProcess.Stderr = &output
+	subProcess.Stdin = os.Stdin
+	subProcess.Stdout = &output
+	err = subProcess.Run()
+	exitCode := -1 // the command could not be started
+	if subProcess.ProcessState != nil {
+		exitCode = subProcess.ProcessState.ExitCode()
+	}
+	event["exitCode"] = exitCode
+	event["output"] = output.String()
+	cli.EmitEvent("subprocess", event)
+	return err
+}
+
+// simulate tracks the effects of the given command in dry-run mode.
+func (shell LoggingShell) simulate(cmd string, args ...string) {
+	if cmd != "git" || len(args) == 0 {
+		return
+	}
+	switch {
+	case len(args) == 2 && args[0] == "checkout":
+		shell.dryRun.ChangeBranch(args[1])
+	case len(args) == 3 && args[0] == "branch" && (args[1] == "-d" || args[1] == "-D"):
+		shell.dryRun.DeleteBranch(args[2])
+	case len(args) == 3 && args[0] == "branch" && !strings.HasPrefix(args[1], "-"):
+		shell.dryRun.CreateBranch(args[1], args[2])
+	case len(args) == 1 && args[0] == "stash":
+		shell.dryRun.Stash()
+	case len(args) == 2 && args[0] == "stash" && args[1] == "pop":
+		shell.dryRun.PopStash()
+	case args[0] == "push" && len(args) > 2 && args[len(args)-3] == "-u":
+		shell.dryRun.PushBranch(args[len(args)-1])
+	case args[0] == "push" && len(args) > 1 && args[len(args)-2] == "-u":
+		shell.dryRun.PushBranch(shell.dryRun.CurrentBranch())
+	}
+}
+
+// newSubProcess provides the subprocess that runs the given command.
+func newSubProcess(cmd string, args ...string) *exec.Cmd {
+	// Windows commands run inside CMD
+	// because opening browsers is done via "start"
+	if runtime.GOOS == "windows" {
+		args = append([]string{"/C", cmd}, args...)
+		cmd = "cmd"
+	}
+	return exec.Command(cmd, args...) // #nosec
+}
@@ -33,6 +33,7 @@ func NewProdRepo(debugFlag *bool) ProdRepo {
 		Config:             config,
 		CurrentBranchCache: &currentBranchTracker,
 		DryRun:             &dryRun,
+		GitDirCache:        &cache.String{},
 		IsRepoCache:        &isRepoCache,
 		RemotesCache:       &remotesCache,
 		RemoteBranchCache:  &remoteBranchCache,
@@ -44,6 +45,7 @@ func NewProdRepo(debugFlag *bool) ProdRepo {
 		Config:             config,
 		CurrentBranchCache: &currentBranchTracker,
 		DryRun:             &dryRun,
+		GitDirCache:        &cache.String{},
 		IsRepoCache:        &isRepoCache,
 		RemotesCache:       &remotesCache,
 		RemoteBranchCache:  &remoteBranchCache,
@@ -58,6 +60,18 @@ func NewProdRepo(debugFlag *bool) ProdRepo {
 	}
 }
 
+// ActivateDryRun makes this repo simulate the changes that Git Town commands make
+// instead of performing them.
+func (r *ProdRepo) ActivateDryRun() error {
+	currentBranch, err := r.Silent.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	r.DryRun.Activate(currentBranch)
+	r.Config.Storage.ActivateDryRun()
+	return nil
+}
+
 // RemoveOutdatedConfiguration removes outdated Git Town configuration.
 func (r *ProdRepo) RemoveOutdatedConfiguration() error {
 	branches, err := r.Silent.LocalAndOriginBranches()
@@ -21,6 +21,7 @@ type Runner struct {
 	Config             config.GitTown // caches Git configuration settings
 	CurrentBranchCache *cache.String  // caches the currently checked out Git branch
 	DryRun             *DryRun        // tracks dry-run information
+	GitDirCache        *cache.String  // caches the Git directory of the current worktree
 	IsRepoCache        *cache.Bool    // caches whether the current directory is a Git repo
 	RemoteBranchCache  *cache.Strings // caches the remote branches of this Git repo
 	RemotesCache       *cache.Strings // caches Git remotes
@@ -45,6 +46,19 @@ func (r *Runner) AbortRebase() error {
 	return nil
 }
 
+// AddCoAuthors adds "Co-authored-by" trailers for the given people to the last commit.
+func (r *Runner) AddCoAuthors(coAuthors []string) error {
+	gitArgs := []string{"commit", "--amend", "--no-edit"}
+	for _, coAuthor := range coAuthors {
+		gitArgs = append(gitArgs, "--trailer", "Co-authored-by: "+coAuthor)
+	}
+	_, err := r.Run("git", gitArgs...)
+	if err != nil {
+		return fmt.Errorf("cannot add co-authors to the last commit: %w", err)
+	}
+	return nil
+}
+
 // AddRemote adds a Git remote with the given name and URL to this repository.
 func (r *Runner) AddRemote(name, url string) error {
 	_, err := r.Run("git", "remote", "add", name, url)
@@ -61,7 +75,31 @@ func (r *Runner) AddSubmodule(url string) error {
 	if err != nil {
 		return fmt.Errorf("cannot add submodule %q: %w", url, err)
 	}
-	return r.Commit("added submodule", "")
+	return r.Commit("added submodule", "", []string{})
+}
+
+// AheadBehind provides how many commits the given branch contains that the given other branch doesn't contain,
+// and how many commits the other branch contains that the given branch doesn't contain.
+//
+//nolint:nonamedreturns  // return values aren't obvious from the function name
+func (r *Runner) AheadBehind(branch, otherBranch string) (ahead, behind int, err error) {
+	out, err := r.Run("git", "rev-list", "--left-right", "--count", branch+"..."+otherBranch)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot compare branch %q with %q: %w", branch, otherBranch, err)
+	}
+	counts := strings.Fields(out.OutputSanitized())
+	if len(counts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected output when comparing branch %q with %q: %q", branch, otherBranch, out.OutputSanitized())
+	}
+	ahead, err = strconv.Atoi(counts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse the number of commits in %q ahead of %q: %w", branch, otherBranch, err)
+	}
+	behind, err = strconv.Atoi(counts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse the number of commits in %q behind %q: %w", branch, otherBranch, err)
+	}
+	return ahead, behind, nil
 }
 
 // Author provides the locally Git configured user.
@@ -79,9 +117,34 @@ func (r *Runner) Author() (string, error) {
 	return name + " <" + email + ">", nil
 }
 
+// BranchAuthors provides the people who authored the commits that the branch with the given name
+// adds to the current branch, starting with the person who authored the most commits.
+// People with several identities appear only once, with their canonical identity from the .mailmap file.
+func (r *Runner) BranchAuthors(branch string) ([]string, error) {
+	if r.DryRun.IsActive() {
+		branch = r.DryRun.ResolveBranch(branch)
+	}
+	// returns lines of "<number of commits>\t<name and email>"
+	out, err := r.Run("git", "shortlog", "-s", "-n", "-e", "HEAD.."+branch)
+	if err != nil {
+		return []string{}, fmt.Errorf("cannot determine the authors of branch %q: %w", branch, err)
+	}
+	result := []string{}
+	for _, line := range out.OutputLines() {
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 2)
+		if len(parts) == 2 {
+			result = append(result, parts[1])
+		}
+	}
+	return result, nil
+}
+
 // BranchHasUnmergedCommits indicates whether the branch with the given name
 // contains commits that are not merged into the main branch.
 func (r *Runner) BranchHasUnmergedCommits(branch string) (bool, error) {
+	if r.DryRun.IsActive() {
+		branch = r.DryRun.ResolveBranch(branch)
+	}
 	out, err := r.Run("git", "log", r.Config.MainBranch()+".."+branch)
 	if err != nil {
 		return false, fmt.Errorf("cannot determine if branch %q has unmerged commits: %w", branch, err)
@@ -89,6 +152,32 @@ func (r *Runner) BranchHasUnmergedCommits(branch string) (bool, error) {
 	return out.OutputSanitized() != "", nil
 }
 
+// BranchOwner provides the person who authored the first commit that the branch with the given name
+// adds to the current branch, with the canonical identity from the .mailmap file.
+func (r *Runner) BranchOwner(branch string) (string, error) {
+	if r.DryRun.IsActive() {
+		branch = r.DryRun.ResolveBranch(branch)
+	}
+	out, err := r.Run("git", "log", "--reverse", "--format=%aN <%aE>", "HEAD.."+branch)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the owner of branch %q: %w", branch, err)
+	}
+	owner := out.OutputLines()[0]
+	if owner == "" {
+		return "", fmt.Errorf("cannot determine the owner of branch %q because it contains no commits", branch)
+	}
+	return owner, nil
+}
+
+// CanonicalAuthor provides the canonical identity of the given person as defined in the .mailmap file.
+func (r *Runner) CanonicalAuthor(author string) (string, error) {
+	out, err := r.Run("git", "check-mailmap", author)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the canonical identity of %q: %w", author, err)
+	}
+	return out.OutputSanitized(), nil
+}
+
 // CheckoutBranch checks out the Git branch with the given name in this repo.
 func (r *Runner) CheckoutBranch(name string) error {
 	_, err := r.Run("git", "checkout", name)
@@ -106,7 +195,14 @@ func (r *Runner) CheckoutBranch(name string) error {
 // CommentOutSquashCommitMessage comments out the message for the current squash merge
 // Adds the given prefix with the newline if provided.
 func (r *Runner) CommentOutSquashCommitMessage(prefix string) error {
-	squashMessageFile := ".git/SQUASH_MSG"
+	if r.DryRun.IsActive() {
+		// the simulated squash merge didn't create a squash message
+		return nil
+	}
+	squashMessageFile, err := r.squashMessageFile()
+	if err != nil {
+		return err
+	}
 	contentBytes, err := os.ReadFile(squashMessageFile)
 	if err != nil {
 		return fmt.Errorf("cannot read squash message file %q: %w", squashMessageFile, err)
@@ -128,6 +224,22 @@ func (r *Runner) CommitNoEdit() error {
 	return nil
 }
 
+// CommitSubjects provides the subjects of the commits that the branch with the given name
+// adds to the current branch, starting with the oldest commit.
+func (r *Runner) CommitSubjects(branch string) ([]string, error) {
+	if r.DryRun.IsActive() {
+		branch = r.DryRun.ResolveBranch(branch)
+	}
+	out, err := r.Run("git", "log", "--reverse", "--format=%s", "HEAD.."+branch)
+	if err != nil {
+		return []string{}, fmt.Errorf("cannot determine the commits of branch %q: %w", branch, err)
+	}
+	if out.OutputSanitized() == "" {
+		return []string{}, nil
+	}
+	return out.OutputLines(), nil
+}
+
 // Commits provides a list of the commits in this Git repository with the given fields.
 func (r *Runner) Commits(fields []string) ([]Commit, error) {
 	branches, err := r.LocalBranchesMainFirst()
@@ -192,7 +304,8 @@ func (r *Runner) CommitStagedChanges(message string) error {
 }
 
 // Commit performs a commit of the staged changes with an optional custom message and author.
-func (r *Runner) Commit(message, author string) error {
+// Adds "Co-authored-by" trailers for the given people.
+func (r *Runner) Commit(message, author string, coAuthors []string) error {
 	gitArgs := []string{"commit"}
 	if message != "" {
 		gitArgs = append(gitArgs, "-m", message)
@@ -200,6 +313,9 @@ func (r *Runner) Commit(message, author string) error {
 	if author != "" {
 		gitArgs = append(gitArgs, "--author", author)
 	}
+	for _, coAuthor := range coAuthors {
+		gitArgs = append(gitArgs, "--trailer", "Co-authored-by: "+coAuthor)
+	}
 	_, err := r.Run("git", gitArgs...)
 	return err
 }
@@ -482,6 +598,16 @@ func (r *Runner) ExpectedPreviouslyCheckedOutBranch(initialPreviouslyCheckedOutB
 	return r.Config.MainBranch(), nil
 }
 
+// FastForward fast-forwards the current branch to the given branch.
+// Fails if the current branch has commits that the given branch doesn't contain.
+func (r *Runner) FastForward(branch string) error {
+	_, err := r.Run("git", "merge", "--ff-only", branch)
+	if err != nil {
+		return fmt.Errorf("cannot fast-forward to branch %q: %w", branch, err)
+	}
+	return nil
+}
+
 // Fetch retrieves the updates from the origin repo.
 func (r *Runner) Fetch() error {
 	_, err := r.Run("git", "fetch", "--prune", "--tags")
@@ -545,6 +671,19 @@ func (r *Runner) FilesInBranch(branch string) ([]string, error) {
 	return result, err
 }
 
+// GitDirectory provides the absolute path of the Git directory of the current worktree,
+// i.e. the ".git" folder of the main worktree or the folder Git uses for a linked worktree.
+func (r *Runner) GitDirectory() (string, error) {
+	if !r.GitDirCache.Initialized() {
+		res, err := r.Run("git", "rev-parse", "--absolute-git-dir")
+		if err != nil {
+			return "", fmt.Errorf("cannot determine the Git directory: %w", err)
+		}
+		r.GitDirCache.Set(filepath.FromSlash(res.OutputSanitized()))
+	}
+	return r.GitDirCache.Value(), nil
+}
+
 // HasBranchesOutOfSync indicates whether one or more local branches are out of sync with their tracking branch.
 func (r *Runner) HasBranchesOutOfSync() (bool, error) {
 	res, err := r.Run("git", "for-each-ref", "--format=%(refname:short) %(upstream:track)", "refs/heads")
@@ -611,6 +750,9 @@ func (r *Runner) HasMergeInProgress() (bool, error) {
 
 // HasOpenChanges indicates whether this repo has open changes.
 func (r *Runner) HasOpenChanges() (bool, error) {
+	if r.DryRun.IsActive() && r.DryRun.StashSize() > 0 {
+		return false, nil
+	}
 	outcome, err := r.Run("git", "status", "--porcelain", "--ignore-submodules")
 	if err != nil {
 		return false, fmt.Errorf("cannot determine open changes: %w", err)
@@ -651,6 +793,9 @@ func (r *Runner) HasRemote(name string) (bool, error) {
 // HasShippableChanges indicates whether the given branch has changes
 // not currently in the main branch.
 func (r *Runner) HasShippableChanges(branch string) (bool, error) {
+	if r.DryRun.IsActive() {
+		branch = r.DryRun.ResolveBranch(branch)
+	}
 	out, err := r.Run("git", "diff", r.Config.MainBranch()+".."+branch)
 	if err != nil {
 		return false, fmt.Errorf("cannot determine whether branch %q has shippable changes: %w", branch, err)
@@ -660,6 +805,9 @@ func (r *Runner) HasShippableChanges(branch string) (bool, error) {
 
 // HasTrackingBranch indicates whether the local branch with the given name has a remote tracking branch.
 func (r *Runner) HasTrackingBranch(name string) (bool, error) {
+	if r.DryRun.IsActive() && (r.DryRun.CreatedBranch(name) || r.DryRun.PushedBranch(name)) {
+		return r.DryRun.PushedBranch(name), nil
+	}
 	trackingBranch := "origin/" + name
 	remoteBranches, err := r.RemoteBranches()
 	if err != nil {
@@ -673,6 +821,19 @@ func (r *Runner) HasTrackingBranch(name string) (bool, error) {
 	return false, nil
 }
 
+// IsAncestor indicates whether the commit with the given SHA is an ancestor of the current commit.
+func (r *Runner) IsAncestor(sha string) (bool, error) {
+	res, err := r.Run("git", "merge-base", "--is-ancestor", sha, "HEAD")
+	if err != nil {
+		// Git signals commits that aren't ancestors via exit code 1
+		if res != nil && res.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot determine whether commit %q is an ancestor of the current commit: %w", sha, err)
+	}
+	return true, nil
+}
+
 // IsBranchInSync returns whether the branch with the given name is in sync with its tracking branch.
 func (r *Runner) IsBranchInSync(branch string) (bool, error) {
 	hasTrackingBranch, err := r.HasTrackingBranch(branch)
@@ -727,6 +888,9 @@ func (r *Runner) LocalAndOriginBranches() ([]string, error) {
 		result[i] = branch
 		i++
 	}
+	if r.DryRun.IsActive() {
+		result = r.DryRun.LocalBranches(result)
+	}
 	sort.Strings(result)
 	mainBranch := r.Config.MainBranchOr("main")
 	return stringslice.Hoist(result, mainBranch), nil
@@ -745,6 +909,9 @@ func (r *Runner) LocalBranches() ([]string, error) {
 		line = strings.TrimSpace(line)
 		result = append(result, line)
 	}
+	if r.DryRun.IsActive() {
+		return r.DryRun.LocalBranches(result), nil
+	}
 	return result, nil
 }
 
@@ -802,6 +969,28 @@ func (r *Runner) MergeBranchNoEdit(branch string) error {
 	return err
 }
 
+// MergeBranchNoFastForward merges the given branch into the current branch
+// with a merge commit that has the given message.
+// If the given message is empty, it opens the editor for the commit message when the user can enter it
+// and uses the default merge commit message of Git otherwise.
+func (r *Runner) MergeBranchNoFastForward(branch, message string, canEdit bool) error {
+	gitArgs := []string{"merge", "--no-ff"}
+	switch {
+	case message != "":
+		gitArgs = append(gitArgs, "-m", message)
+	case canEdit:
+		gitArgs = append(gitArgs, "--edit")
+	default:
+		gitArgs = append(gitArgs, "--no-edit")
+	}
+	gitArgs = append(gitArgs, branch)
+	_, err := r.Run("git", gitArgs...)
+	if err != nil {
+		return fmt.Errorf("cannot merge branch %q: %w", branch, err)
+	}
+	return nil
+}
+
 // PopStash restores stashed-away changes into the workspace.
 func (r *Runner) PopStash() error {
 	_, err := r.Run("git", "stash", "pop")
@@ -811,6 +1000,25 @@ func (r *Runner) PopStash() error {
 	return nil
 }
 
+// PrefillSquashCommitMessage adds the given message before the commented out message for the current squash merge,
+// so that the editor for the squash commit starts out with it.
+func (r *Runner) PrefillSquashCommitMessage(message string) error {
+	if r.DryRun.IsActive() {
+		// the simulated squash merge didn't create a squash message
+		return nil
+	}
+	squashMessageFile, err := r.squashMessageFile()
+	if err != nil {
+		return err
+	}
+	contentBytes, err := os.ReadFile(squashMessageFile)
+	if err != nil {
+		return fmt.Errorf("cannot read squash message file %q: %w", squashMessageFile, err)
+	}
+	content := message + "\n\n" + string(contentBytes)
+	return os.WriteFile(squashMessageFile, []byte(content), 0o600)
+}
+
 // PreviouslyCheckedOutBranch provides the name of the branch that was previously checked out in this repo.
 func (r *Runner) PreviouslyCheckedOutBranch() (string, error) {
 	outcome, err := r.Run("git", "rev-parse", "--verify", "--abbrev-ref", "@{-1}")
@@ -974,6 +1182,28 @@ func (r *Runner) RevertCommit(sha string) error {
 	return nil
 }
 
+// RevertCommits reverts the commits that the commit with the given SHA added
+// on top of the commit with the given previous SHA, starting with the newest one.
+// Merge commits in this range only combine commits that are part of the previous SHA
+// or get reverted individually, hence this doesn't revert them.
+func (r *Runner) RevertCommits(previousSha, sha string) error {
+	_, err := r.Run("git", "revert", "--no-merges", previousSha+".."+sha)
+	if err != nil {
+		return fmt.Errorf("cannot revert the commits between %q and %q: %w", previousSha, sha, err)
+	}
+	return nil
+}
+
+// RevertMergeCommit reverts the merge commit with the given SHA
+// by undoing the changes it brought into its first parent.
+func (r *Runner) RevertMergeCommit(sha string) error {
+	_, err := r.Run("git", "revert", "-m", "1", sha)
+	if err != nil {
+		return fmt.Errorf("cannot revert merge commit %q: %w", sha, err)
+	}
+	return nil
+}
+
 // RootDirectory provides the path of the rood directory of the current repository,
 // i.e. the directory that contains the ".git" folder.
 func (r *Runner) RootDirectory() (string, error) {
@@ -989,6 +1219,9 @@ func (r *Runner) RootDirectory() (string, error) {
 
 // ShaForBranch provides the SHA for the local branch with the given name.
 func (r *Runner) ShaForBranch(name string) (string, error) {
+	if r.DryRun.IsActive() {
+		name = r.DryRun.ResolveBranch(name)
+	}
 	outcome, err := r.Run("git", "rev-parse", name)
 	if err != nil {
 		return "", fmt.Errorf("cannot determine SHA of local branch %q: %w", name, err)
@@ -1013,6 +1246,10 @@ func (r *Runner) ShaForCommit(name string) (string, error) {
 // ShouldPushBranch returns whether the local branch with the given name
 // contains commits that have not been pushed to its tracking branch.
 func (r *Runner) ShouldPushBranch(branch string) (bool, error) {
+	if r.DryRun.IsActive() && (r.DryRun.CreatedBranch(branch) || r.DryRun.PushedBranch(branch)) {
+		// the tracking branch of branches created during the dry run doesn't exist yet
+		return !r.DryRun.PushedBranch(branch), nil
+	}
 	trackingBranch := r.TrackingBranch(branch)
 	out, err := r.Run("git", "rev-list", "--left-right", branch+"..."+trackingBranch)
 	if err != nil {
@@ -1030,6 +1267,16 @@ func (r *Runner) SquashMerge(branch string) error {
 	return nil
 }
 
+// squashMessageFile provides the path of the file containing the message for the current squash merge.
+// Worktrees and submodules keep it in their own Git directory.
+func (r *Runner) squashMessageFile() (string, error) {
+	gitDir, err := r.GitDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "SQUASH_MSG"), nil
+}
+
 // Stash adds the current files to the Git stash.
 func (r *Runner) Stash() error {
 	err := r.RunMany([][]string{
@@ -1048,10 +1295,14 @@ func (r *Runner) StashSize() (int, error) {
 	if err != nil {
 		return 0, fmt.Errorf("command %q failed: %w", res.FullCmd(), err)
 	}
-	if res.OutputSanitized() == "" {
-		return 0, nil
+	result := 0
+	if res.OutputSanitized() != "" {
+		result = len(res.OutputLines())
 	}
-	return len(res.OutputLines()), nil
+	if r.DryRun.IsActive() {
+		result += r.DryRun.StashSize()
+	}
+	return result, nil
 }
 
 // Tags provides a list of the tags in this repository.
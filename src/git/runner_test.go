@@ -28,6 +28,33 @@ func TestRunner(t *testing.T) {
 		assert.Equal(t, []string{"origin"}, remotes)
 	})
 
+	t.Run(".AheadBehind()", func(t *testing.T) {
+		t.Parallel()
+		runner := test.CreateRepo(t).Runner
+		err := runner.CreateBranch("branch1", "initial")
+		assert.NoError(t, err)
+		err = runner.CreateCommit(git.Commit{
+			Branch:      "branch1",
+			FileName:    "file1",
+			FileContent: "hello",
+			Message:     "branch1 commit",
+		})
+		assert.NoError(t, err)
+		for _, message := range []string{"initial commit 1", "initial commit 2"} {
+			err = runner.CreateCommit(git.Commit{
+				Branch:      "initial",
+				FileName:    message,
+				FileContent: message,
+				Message:     message,
+			})
+			assert.NoError(t, err)
+		}
+		ahead, behind, err := runner.AheadBehind("branch1", "initial")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, ahead)
+		assert.Equal(t, 2, behind)
+	})
+
 	t.Run(".CheckoutBranch()", func(t *testing.T) {
 		t.Parallel()
 		runner := test.CreateRepo(t).Runner
@@ -485,6 +512,34 @@ func TestRunner(t *testing.T) {
 		assert.False(t, has)
 	})
 
+	t.Run(".IsAncestor()", func(t *testing.T) {
+		t.Parallel()
+		runner := test.CreateRepo(t).Runner
+		err := runner.CreateBranch("branch1", "initial")
+		assert.NoError(t, err)
+		err = runner.CreateCommit(git.Commit{
+			Branch:      "branch1",
+			FileName:    "file1",
+			FileContent: "hello",
+			Message:     "branch1 commit",
+		})
+		assert.NoError(t, err)
+		initialSha, err := runner.ShaForBranch("initial")
+		assert.NoError(t, err)
+		branchSha, err := runner.ShaForBranch("branch1")
+		assert.NoError(t, err)
+		err = runner.CheckoutBranch("initial")
+		assert.NoError(t, err)
+		isAncestor, err := runner.IsAncestor(branchSha)
+		assert.NoError(t, err)
+		assert.False(t, isAncestor)
+		err = runner.CheckoutBranch("branch1")
+		assert.NoError(t, err)
+		isAncestor, err = runner.IsAncestor(initialSha)
+		assert.NoError(t, err)
+		assert.True(t, isAncestor)
+	})
+
 	t.Run(".LocalBranchesMainFirst()", func(t *testing.T) {
 		t.Parallel()
 		origin := test.CreateRepo(t)
@@ -523,6 +578,31 @@ func TestRunner(t *testing.T) {
 		assert.Equal(t, []string{"b1", "b2", "b3", "initial"}, branches)
 	})
 
+	t.Run(".PrefillSquashCommitMessage()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("in a worktree", func(t *testing.T) {
+			t.Parallel()
+			repo := test.CreateRepo(t)
+			err := repo.CreateBranch("feature", "initial")
+			assert.NoError(t, err)
+			err = repo.CreateCommit(git.Commit{Branch: "feature", FileName: "file", Message: "feature commit"})
+			assert.NoError(t, err)
+			worktreeDir := filepath.Join(t.TempDir(), "worktree")
+			_, err = repo.Run("git", "worktree", "add", worktreeDir, "initial")
+			assert.NoError(t, err)
+			worktree := test.NewRepo(worktreeDir, t.TempDir(), "")
+			err = worktree.SquashMerge("feature")
+			assert.NoError(t, err)
+			err = worktree.PrefillSquashCommitMessage("feature done")
+			assert.NoError(t, err)
+			gitDir, err := worktree.GitDirectory()
+			assert.NoError(t, err)
+			content, err := os.ReadFile(filepath.Join(gitDir, "SQUASH_MSG"))
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(content), "feature done\n\n"))
+		})
+	})
+
 	t.Run(".PreviouslyCheckedOutBranch()", func(t *testing.T) {
 		t.Parallel()
 		runner := test.CreateRepo(t).Runner
@@ -15,6 +15,9 @@ type Parts struct {
 
 func Parse(url string) *Parts {
 	patterns := []string{
+		// Azure DevOps clone URLs: https://dev.azure.com/org/project/_git/repo, git@ssh.dev.azure.com:v3/org/project/repo
+		`^[^:]+://(?P<user>.*@)?(?P<host>[^/]*\/)(?P<org>.+\/)_git\/(?P<repo>[^/]+?)(?:\.git)?$`,
+		`^(?P<user>.*@)?(?P<host>[^/:]*:)v3\/(?P<org>[^/]+\/[^/]+\/)(?P<repo>[^/]+?)(?:\.git)?$`,
 		`^[^:]+://(?P<user>.*@)?(?P<host>.*\/)(?P<org>.*\/)(?P<repo>.*)\.git$`,
 		`^[^:]+://(?P<user>.*@)?(?P<host>.*\/)(?P<org>.*\/)(?P<repo>.*)$`,
 		`^(?P<user>.*@)?(?P<host>.*?[:/])(?P<org>.*\/)(?P<repo>.*)\.git$`,
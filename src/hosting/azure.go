@@ -0,0 +1,384 @@
+package hosting
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/git-town/git-town/v7/src/config"
+)
+
+// AzureConnector provides access to the API of Azure DevOps Repos.
+// Repositories on Azure DevOps live at "/<organization>/<project>/_git/<repo>",
+// the Organization field therefore contains the organization and project.
+type AzureConnector struct {
+	CommonConfig
+	// APIURL is the base URL of the Azure DevOps REST API for the project that contains the repository
+	APIURL string
+	// CompletionPollInterval and CompletionTimeout define how MergeProposal waits
+	// for Azure DevOps to complete pull requests in the background
+	CompletionPollInterval time.Duration
+	CompletionTimeout      time.Duration
+	log                    logFn
+}
+
+// azureAPIVersion is the version of the Azure DevOps REST API that AzureConnector talks to.
+const azureAPIVersion = "7.0"
+
+// NewAzureConnector provides an Azure DevOps connector instance if the current repo is hosted on Azure DevOps,
+// otherwise nil.
+func NewAzureConnector(gitConfig gitTownConfig, log logFn) (*AzureConnector, error) {
+	hostingService, err := gitConfig.HostingService()
+	if err != nil {
+		return nil, err
+	}
+	url := gitConfig.OriginURL()
+	if url == nil || (!isAzureHost(url.Host) && hostingService != config.HostingServiceAzure) {
+		return nil, nil //nolint:nilnil
+	}
+	// SSH remotes use a dedicated host, the web UI and the API are on the main host
+	hostname := url.Host
+	if hostname == "ssh.dev.azure.com" {
+		hostname = "dev.azure.com"
+	}
+	commonConfig := newCommonConfig(gitConfig, gitConfig.APIToken(config.HostingServiceAzure, hostname).Value, hostname, url)
+	return &AzureConnector{
+		APIURL:                 fmt.Sprintf("https://%s/%s/_apis", hostname, commonConfig.Organization),
+		CompletionPollInterval: 2 * time.Second,
+		CompletionTimeout:      2 * time.Minute,
+		CommonConfig:           commonConfig,
+		log:                    log,
+	}, nil
+}
+
+func (c *AzureConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	return unsupportedAutoMergeError("Azure DevOps")
+}
+
+func (c *AzureConnector) CancelAutoMerge(number int) error {
+	return unsupportedAutoMergeError("Azure DevOps")
+}
+
+func (c *AzureConnector) CloseProposal(number int) error {
+	return fmt.Errorf("closing Azure DevOps pull requests via the API is currently not supported")
+}
+
+func (c *AzureConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	return nil, fmt.Errorf("creating Azure DevOps pull requests via the API is currently not supported")
+}
+
+func (c *AzureConnector) DefaultProposalMessage(proposal Proposal) string {
+	return fmt.Sprintf("Merged PR %d: %s", proposal.Number, proposal.Title)
+}
+
+func (c *AzureConnector) FindProposal(branch, target string) (*Proposal, error) {
+	query := url.Values{}
+	query.Add("searchCriteria.sourceRefName", "refs/heads/"+branch)
+	query.Add("searchCriteria.targetRefName", "refs/heads/"+target)
+	query.Add("searchCriteria.status", "active")
+	var result azurePullRequestList
+	err := c.api().call(http.MethodGet, c.repoEndpoint("/pullrequests", query), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests := []azurePullRequest{}
+	for _, pullRequest := range result.Value {
+		if c.isFromOrigin(pullRequest) {
+			pullRequests = append(pullRequests, pullRequest)
+		}
+	}
+	if len(pullRequests) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	if len(pullRequests) > 1 {
+		return nil, fmt.Errorf("found %d pull requests from branch %q into branch %q", len(pullRequests), branch, target)
+	}
+	proposal := parseAzurePullRequest(pullRequests[0], c.RepositoryURL())
+	return &proposal, nil
+}
+
+func (c *AzureConnector) HostingServiceName() string {
+	return "Azure DevOps"
+}
+
+//nolint:nonamedreturns
+func (c *AzureConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error) {
+	if number <= 0 {
+		return "", fmt.Errorf("no pull request number given")
+	}
+	mergeStrategy, err := azureMergeStrategy(strategy)
+	if err != nil {
+		return "", err
+	}
+	if c.log != nil {
+		c.log("Azure DevOps API: completing PR #%d\n", number)
+	}
+	// Azure DevOps completes pull requests only for the commit that the caller has seen
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return "", err
+	}
+	payload := map[string]interface{}{
+		"status":                "completed",
+		"lastMergeSourceCommit": pullRequest.LastMergeSourceCommit,
+		"completionOptions": map[string]interface{}{
+			"mergeStrategy":      mergeStrategy,
+			"mergeCommitMessage": message,
+			// the branch will be deleted by Git Town
+			"deleteSourceBranch": false,
+		},
+	}
+	var result azurePullRequest
+	err = c.api().call(http.MethodPatch, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number), nil), payload, &result)
+	if err != nil {
+		return "", err
+	}
+	if result.Status == "completed" {
+		return result.LastMergeCommit.CommitID, nil
+	}
+	// Azure DevOps usually accepts the completion and merges the pull request in the background
+	return c.waitForCompletion(number)
+}
+
+// waitForCompletion waits until Azure DevOps has completed the pull request with the given number
+// and provides the SHA of the resulting commit on the target branch.
+func (c *AzureConnector) waitForCompletion(number int) (string, error) {
+	if c.log != nil {
+		c.log("Azure DevOps API: waiting for PR #%d to complete\n", number)
+	}
+	deadline := time.Now().Add(c.CompletionTimeout)
+	for {
+		pullRequest, err := c.loadPullRequest(number)
+		if err != nil {
+			return "", err
+		}
+		switch pullRequest.Status {
+		case "completed":
+			return pullRequest.LastMergeCommit.CommitID, nil
+		case "abandoned":
+			return "", fmt.Errorf("pull request #%d got abandoned instead of completed", number)
+		}
+		switch pullRequest.MergeStatus {
+		case "conflicts", "failure", "rejectedByPolicy":
+			return "", fmt.Errorf("cannot complete pull request #%d because its merge status is %q", number, pullRequest.MergeStatus)
+		}
+		if !time.Now().Before(deadline) {
+			return "", fmt.Errorf("pull request #%d is not completed after %s, Azure DevOps might still complete it in the background, please check its status before shipping again", number, c.CompletionTimeout)
+		}
+		time.Sleep(c.CompletionPollInterval)
+	}
+}
+
+func (c *AzureConnector) NewProposalURL(branch, parentBranch string) (string, error) {
+	query := url.Values{}
+	query.Add("sourceRef", branch)
+	query.Add("targetRef", parentBranch)
+	// pull requests from forks get created in the fork
+	return fmt.Sprintf("https://%s/%s/_git/%s/pullrequestcreate?%s", c.Hostname, c.originOrganization(), c.originRepository(), query.Encode()), nil
+}
+
+func (c *AzureConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	var result azurePullRequestStatusList
+	err := c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d/statuses", number), nil), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]CIStatus, len(result.Value))
+	for s, status := range result.Value {
+		statuses[s] = parseAzureStatusState(status.State)
+	}
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return nil, err
+	}
+	approvals := 0
+	for _, reviewer := range pullRequest.Reviewers {
+		// Azure DevOps votes 10 for "approved" and 5 for "approved with suggestions"
+		if reviewer.Vote >= 5 {
+			approvals++
+		}
+	}
+	// the branch policies that define the merge requirements apply to the whole project, Git Town doesn't evaluate them
+	return &ProposalChecks{
+		Approvals:         approvals,
+		CIStatus:          combineCIStatuses(statuses...),
+		RequiredApprovals: 0,
+		RequiredChecks:    []CheckResult{},
+	}, nil
+}
+
+func (c *AzureConnector) ProposalMerged(number int) (bool, error) {
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return false, err
+	}
+	return pullRequest.Status == "completed", nil
+}
+
+func (c *AzureConnector) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/_git/%s", c.Hostname, c.Organization, c.Repository)
+}
+
+func (c *AzureConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("Azure DevOps API: updating description of PR #%d\n", number)
+	}
+	payload := map[string]interface{}{
+		"description": body,
+	}
+	return c.api().call(http.MethodPatch, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number), nil), payload, nil)
+}
+
+func (c *AzureConnector) UpdateProposalTarget(number int, target string) error {
+	if c.log != nil {
+		c.log("Azure DevOps API: updating target branch for PR #%d to %q\n", number, target)
+	}
+	payload := map[string]interface{}{
+		"targetRefName": "refs/heads/" + target,
+	}
+	return c.api().call(http.MethodPatch, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number), nil), payload, nil)
+}
+
+// api provides a client for the Azure DevOps REST API.
+func (c *AzureConnector) api() restClient {
+	return restClient{
+		authorize: func(request *http.Request) {
+			// personal access tokens use basic auth with an empty username
+			if c.APIToken != "" {
+				request.SetBasicAuth("", c.APIToken)
+			}
+		},
+		serviceName: "Azure DevOps",
+	}
+}
+
+// isFromOrigin indicates whether the given pull request merges a branch of the repo at the origin remote.
+func (c *AzureConnector) isFromOrigin(pullRequest azurePullRequest) bool {
+	if pullRequest.ForkSource == nil {
+		return !c.IsFork()
+	}
+	// the organization of Azure DevOps repos consists of the organization and the project
+	project := c.ForkOrganization[strings.LastIndex(c.ForkOrganization, "/")+1:]
+	repo := pullRequest.ForkSource.Repository
+	return c.IsFork() && repo.Name == c.ForkRepository && repo.Project.Name == project
+}
+
+// loadPullRequest provides the pull request with the given number.
+func (c *AzureConnector) loadPullRequest(number int) (*azurePullRequest, error) {
+	var result azurePullRequest
+	err := c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number), nil), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// repoEndpoint provides the URL of the given path within the API endpoint for the current repository.
+func (c *AzureConnector) repoEndpoint(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", azureAPIVersion)
+	return fmt.Sprintf("%s/git/repositories/%s%s?%s", c.APIURL, c.Repository, path, query.Encode())
+}
+
+// azureMergeStrategy provides the Azure DevOps merge strategy for the given ship strategy.
+func azureMergeStrategy(strategy config.ShipStrategy) (string, error) {
+	switch strategy {
+	case config.ShipStrategySquash:
+		return "squash", nil
+	case config.ShipStrategyMerge:
+		return "noFastForward", nil
+	case config.ShipStrategyRebase:
+		return "rebase", nil
+	case config.ShipStrategyFastForward:
+	}
+	return "", unsupportedShipStrategyError("Azure DevOps", strategy)
+}
+
+// isAzureHost indicates whether the given hostname belongs to the cloud version of Azure DevOps.
+func isAzureHost(hostname string) bool {
+	return hostname == "dev.azure.com" || hostname == "ssh.dev.azure.com" || strings.HasSuffix(hostname, ".visualstudio.com")
+}
+
+// *************************************
+// Azure DevOps API data structures
+// *************************************
+
+type azureCommit struct {
+	CommitID string `json:"commitId"`
+}
+
+type azureForkSource struct {
+	Repository azureRepository `json:"repository"`
+}
+
+type azureProject struct {
+	Name string `json:"name"`
+}
+
+type azurePullRequest struct {
+	Description           string           `json:"description"`
+	ForkSource            *azureForkSource `json:"forkSource"`
+	IsDraft               bool             `json:"isDraft"`
+	LastMergeCommit       azureCommit      `json:"lastMergeCommit"`
+	LastMergeSourceCommit azureCommit      `json:"lastMergeSourceCommit"`
+	MergeStatus           string           `json:"mergeStatus"`
+	PullRequestID         int              `json:"pullRequestId"`
+	Reviewers             []azureReviewer  `json:"reviewers"`
+	Status                string           `json:"status"`
+	TargetRefName         string           `json:"targetRefName"`
+	Title                 string           `json:"title"`
+}
+
+type azurePullRequestList struct {
+	Value []azurePullRequest `json:"value"`
+}
+
+type azureRepository struct {
+	Name    string       `json:"name"`
+	Project azureProject `json:"project"`
+}
+
+type azureReviewer struct {
+	Vote int `json:"vote"`
+}
+
+type azurePullRequestStatus struct {
+	State string `json:"state"`
+}
+
+type azurePullRequestStatusList struct {
+	Value []azurePullRequestStatus `json:"value"`
+}
+
+// parseAzurePullRequest extracts standardized proposal data from the given Azure DevOps pull request.
+// The API doesn't provide the URL of the web page of pull requests, it is derived from the given repository URL.
+func parseAzurePullRequest(pullRequest azurePullRequest, repositoryURL string) Proposal {
+	return Proposal{
+		Body:            pullRequest.Description,
+		Draft:           pullRequest.IsDraft,
+		Number:          pullRequest.PullRequestID,
+		Target:          strings.TrimPrefix(pullRequest.TargetRefName, "refs/heads/"),
+		Title:           pullRequest.Title,
+		URL:             fmt.Sprintf("%s/pullrequest/%d", repositoryURL, pullRequest.PullRequestID),
+		CanMergeWithAPI: pullRequest.MergeStatus == "succeeded",
+	}
+}
+
+// parseAzureStatusState provides the CI status for the given state of an Azure DevOps pull request status.
+func parseAzureStatusState(state string) CIStatus {
+	switch state {
+	case "succeeded":
+		return CIStatusSuccess
+	case "pending":
+		return CIStatusPending
+	case "failed", "error":
+		return CIStatusFailure
+	default:
+		// "notSet" and "notApplicable" don't report a result
+		return CIStatusNone
+	}
+}
@@ -0,0 +1,339 @@
+package hosting_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAzureConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("HTTPS remote", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "https://org@dev.azure.com/org/project/_git/repo",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "Azure DevOps", connector.HostingServiceName())
+		assert.Equal(t, "https://dev.azure.com/org/project/_git/repo", connector.RepositoryURL())
+		assert.Equal(t, "https://dev.azure.com/org/project/_apis", connector.APIURL)
+	})
+
+	t.Run("SSH remote", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "git@ssh.dev.azure.com:v3/org/project/repo",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://dev.azure.com/org/project/_git/repo", connector.RepositoryURL())
+	})
+
+	t.Run("legacy visualstudio.com remote", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "https://org.visualstudio.com/project/_git/repo",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://org.visualstudio.com/project/_git/repo", connector.RepositoryURL())
+	})
+
+	t.Run("self-hosted Azure DevOps Server", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "azure",
+			originURL:      "https://tfs.example.com/tfs/collection/project/_git/repo",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://tfs.example.com/tfs/collection/project/_git/repo", connector.RepositoryURL())
+		assert.Equal(t, "https://tfs.example.com/tfs/collection/project/_apis", connector.APIURL)
+	})
+
+	t.Run("fork of an upstream repository", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL:   "https://org@dev.azure.com/org/fork-project/_git/repo-fork",
+			upstreamURL: "https://org@dev.azure.com/org/project/_git/repo",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://dev.azure.com/org/project/_git/repo", connector.RepositoryURL())
+		assert.Equal(t, "https://dev.azure.com/org/project/_apis", connector.APIURL)
+		proposalURL, err := connector.NewProposalURL("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://dev.azure.com/org/fork-project/_git/repo-fork/pullrequestcreate?sourceRef=feature&targetRef=main", proposalURL)
+	})
+
+	t.Run("repo is hosted somewhere else", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "git@github.com:git-town/git-town.git",
+		}
+		connector, err := hosting.NewAzureConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+	})
+}
+
+func TestAzureConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("DefaultProposalMessage", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestAzureConnector("")
+		give := hosting.Proposal{ //nolint:exhaustruct
+			Number: 1,
+			Title:  "my title",
+		}
+		assert.Equal(t, "Merged PR 1: my title", connector.DefaultProposalMessage(give))
+	})
+
+	t.Run("NewProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestAzureConnector("")
+		have, err := connector.NewProposalURL("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://dev.azure.com/org/project/_git/repo/pullrequestcreate?sourceRef=feature&targetRef=main", have)
+	})
+
+	t.Run("FindProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/git/repositories/repo/pullrequests", r.URL.Path)
+			assert.Equal(t, "refs/heads/feature", r.URL.Query().Get("searchCriteria.sourceRefName"))
+			assert.Equal(t, "refs/heads/main", r.URL.Query().Get("searchCriteria.targetRefName"))
+			assert.Equal(t, "active", r.URL.Query().Get("searchCriteria.status"))
+			assert.Equal(t, "7.0", r.URL.Query().Get("api-version"))
+			_, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "apiToken", password)
+			fmt.Fprint(w, `{"count": 1, "value": [{"pullRequestId": 1, "title": "my title", "description": "my body", "status": "active", "mergeStatus": "succeeded", "targetRefName": "refs/heads/main"}]}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		want := &hosting.Proposal{
+			Body:            "my body",
+			Draft:           false,
+			Number:          1,
+			Target:          "main",
+			Title:           "my title",
+			URL:             "https://dev.azure.com/org/project/_git/repo/pullrequest/1",
+			CanMergeWithAPI: true,
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("FindProposal in a fork", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/git/repositories/repo/pullrequests", r.URL.Path)
+			fmt.Fprint(w, `{"count": 3, "value": [
+				{"pullRequestId": 1, "title": "upstream", "targetRefName": "refs/heads/main"},
+				{"pullRequestId": 2, "title": "other fork", "targetRefName": "refs/heads/main", "forkSource": {"repository": {"name": "repo", "project": {"name": "other-project"}}}},
+				{"pullRequestId": 3, "title": "my fork", "targetRefName": "refs/heads/main", "forkSource": {"repository": {"name": "repo-fork", "project": {"name": "fork-project"}}}}
+			]}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		connector.ForkOrganization = "org/fork-project"
+		connector.ForkRepository = "repo-fork"
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, have.Number)
+		assert.Equal(t, "my fork", have.Title)
+	})
+
+	t.Run("FindProposal without pull request", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"count": 0, "value": []}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Nil(t, have)
+	})
+
+	t.Run("ProposalChecks", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			switch r.URL.Path {
+			case "/git/repositories/repo/pullrequests/1/statuses":
+				fmt.Fprint(w, `{"value": [{"state": "succeeded"}, {"state": "notApplicable"}, {"state": "failed"}]}`)
+			case "/git/repositories/repo/pullrequests/1":
+				fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "reviewers": [{"vote": 10}, {"vote": 5}, {"vote": -5}]}`)
+			default:
+				t.Errorf("unexpected request to %q", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		have, err := connector.ProposalChecks(1)
+		assert.NoError(t, err)
+		want := &hosting.ProposalChecks{
+			Approvals:         2,
+			CIStatus:          hosting.CIStatusFailure,
+			RequiredApprovals: 0,
+			RequiredChecks:    []hosting.CheckResult{},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("MergeProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/git/repositories/repo/pullrequests/1", r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "lastMergeSourceCommit": {"commitId": "def456"}}`)
+			case http.MethodPatch:
+				var payload map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "completed", payload["status"])
+				assert.Equal(t, map[string]interface{}{"commitId": "def456"}, payload["lastMergeSourceCommit"])
+				wantOptions := map[string]interface{}{
+					"mergeStrategy":      "squash",
+					"mergeCommitMessage": "title\n\nbody",
+					"deleteSourceBranch": false,
+				}
+				assert.Equal(t, wantOptions, payload["completionOptions"])
+				fmt.Fprint(w, `{"pullRequestId": 1, "status": "completed", "lastMergeCommit": {"commitId": "abc123"}}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategySquash, "title\n\nbody")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+	})
+
+	t.Run("MergeProposal completed in the background", func(t *testing.T) {
+		t.Parallel()
+		loads := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				loads++
+				switch loads {
+				case 1:
+					fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "lastMergeSourceCommit": {"commitId": "def456"}}`)
+				case 2:
+					fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "mergeStatus": "queued"}`)
+				default:
+					fmt.Fprint(w, `{"pullRequestId": 1, "status": "completed", "lastMergeCommit": {"commitId": "abc123"}}`)
+				}
+			case http.MethodPatch:
+				fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "mergeStatus": "queued"}`)
+			}
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategySquash, "title\n\nbody")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+		assert.Equal(t, 3, loads)
+	})
+
+	t.Run("MergeProposal not completed in time", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "mergeStatus": "queued"}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		_, err := connector.MergeProposal(1, config.ShipStrategySquash, "message")
+		assert.ErrorContains(t, err, "not completed")
+	})
+
+	t.Run("MergeProposal with merge conflicts", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"pullRequestId": 1, "status": "active", "mergeStatus": "conflicts"}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		_, err := connector.MergeProposal(1, config.ShipStrategySquash, "message")
+		assert.ErrorContains(t, err, `merge status is "conflicts"`)
+	})
+
+	t.Run("MergeProposal with unsupported strategy", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestAzureConnector("")
+		_, err := connector.MergeProposal(1, config.ShipStrategyFastForward, "message")
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeProposal without pull request number", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestAzureConnector("")
+		_, err := connector.MergeProposal(0, config.ShipStrategySquash, "message")
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateProposalBody", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/git/repositories/repo/pullrequests/2", r.URL.Path)
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]interface{}{"description": "new body"}, payload)
+			fmt.Fprint(w, `{"pullRequestId": 2}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		err := connector.UpdateProposalBody(2, "new body")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/git/repositories/repo/pullrequests/2", r.URL.Path)
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, map[string]interface{}{"targetRefName": "refs/heads/main"}, payload)
+			fmt.Fprint(w, `{"pullRequestId": 2}`)
+		}))
+		defer server.Close()
+		connector := newTestAzureConnector(server.URL)
+		err := connector.UpdateProposalTarget(2, "main")
+		assert.NoError(t, err)
+	})
+}
+
+func newTestAzureConnector(apiURL string) *hosting.AzureConnector {
+	return &hosting.AzureConnector{ //nolint:exhaustruct
+		APIURL:                 apiURL,
+		CompletionPollInterval: time.Millisecond,
+		CompletionTimeout:      50 * time.Millisecond,
+		CommonConfig: hosting.CommonConfig{ //nolint:exhaustruct
+			APIToken:     "apiToken",
+			Hostname:     "dev.azure.com",
+			Organization: "org/project",
+			Repository:   "repo",
+		},
+	}
+}
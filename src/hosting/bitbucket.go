@@ -1,8 +1,8 @@
 package hosting
 
 import (
-	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -12,13 +12,15 @@ import (
 // BitbucketConnector provides access to the API of Bitbucket installations.
 type BitbucketConnector struct {
 	CommonConfig
-	organization string
-	git          gitRunner
+	// APIURL is the base URL of the Bitbucket Cloud REST API
+	APIURL string
+	git    gitRunner
+	log    logFn
 }
 
 // NewBitbucketConnector provides a Bitbucket connector instance if the current repo is hosted on Bitbucket,
 // otherwise nil.
-func NewBitbucketConnector(gitConfig gitTownConfig, git gitRunner) (*BitbucketConnector, error) {
+func NewBitbucketConnector(gitConfig gitTownConfig, git gitRunner, log logFn) (*BitbucketConnector, error) {
 	hostingService, err := gitConfig.HostingService()
 	if err != nil {
 		return nil, err
@@ -28,19 +30,55 @@ func NewBitbucketConnector(gitConfig gitTownConfig, git gitRunner) (*BitbucketCo
 		return nil, nil //nolint:nilnil
 	}
 	return &BitbucketConnector{
-		CommonConfig: CommonConfig{
-			APIToken:     "",
-			Hostname:     url.Host,
-			Organization: url.Org,
-			Repository:   url.Repo,
-		},
-		organization: url.Org,
+		APIURL:       "https://api.bitbucket.org/2.0",
+		CommonConfig: newCommonConfig(gitConfig, gitConfig.APIToken(config.HostingServiceBitbucket, url.Host).Value, url.Host, url),
 		git:          git,
+		log:          log,
 	}, nil
 }
 
+func (c *BitbucketConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	return unsupportedAutoMergeError("Bitbucket")
+}
+
+func (c *BitbucketConnector) CancelAutoMerge(number int) error {
+	return unsupportedAutoMergeError("Bitbucket")
+}
+
+func (c *BitbucketConnector) CloseProposal(number int) error {
+	return fmt.Errorf("closing Bitbucket pull requests via the API is currently not supported")
+}
+
+func (c *BitbucketConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	return nil, fmt.Errorf("creating Bitbucket pull requests via the API is currently not supported")
+}
+
 func (c *BitbucketConnector) FindProposal(branch, target string) (*Proposal, error) {
-	return nil, fmt.Errorf("BitBucket API functionality isn't implemented yet")
+	if c.APIToken == "" {
+		// Bitbucket doesn't provide the pull requests of private repositories without authentication,
+		// without a token Git Town therefore ships locally
+		return nil, nil //nolint:nilnil
+	}
+	filter := fmt.Sprintf(`source.branch.name = %q AND destination.branch.name = %q AND state = "OPEN"`, branch, target)
+	if c.IsFork() {
+		// the upstream repo also contains the pull requests of other forks
+		filter += fmt.Sprintf(` AND source.repository.full_name = %q`, c.ForkOrganization+"/"+c.ForkRepository)
+	}
+	query := url.Values{}
+	query.Add("q", filter)
+	var result bitbucketPullRequestList
+	err := c.api().call(http.MethodGet, c.repoEndpoint("/pullrequests?"+query.Encode()), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Values) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	if len(result.Values) > 1 {
+		return nil, fmt.Errorf("found %d pull requests from branch %q into branch %q", len(result.Values), branch, target)
+	}
+	proposal := parseBitbucketPullRequest(result.Values[0])
+	return &proposal, nil
 }
 
 func (c *BitbucketConnector) DefaultProposalMessage(proposal Proposal) string {
@@ -51,26 +89,218 @@ func (c *BitbucketConnector) HostingServiceName() string {
 	return "Bitbucket"
 }
 
+//nolint:nonamedreturns
+func (c *BitbucketConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error) {
+	if number <= 0 {
+		return "", fmt.Errorf("no pull request number given")
+	}
+	if c.APIToken == "" {
+		return "", fmt.Errorf("merging Bitbucket pull requests requires an API token, please configure it via \"git config %s <token>\"", config.BitbucketTokenKey)
+	}
+	mergeStrategy, err := bitbucketMergeStrategy(strategy)
+	if err != nil {
+		return "", err
+	}
+	if c.log != nil {
+		c.log("Bitbucket API: merging PR #%d\n", number)
+	}
+	payload := map[string]interface{}{
+		"message":        message,
+		"merge_strategy": mergeStrategy,
+		// the branch will be deleted by Git Town
+		"close_source_branch": false,
+	}
+	var result bitbucketPullRequest
+	err = c.api().call(http.MethodPost, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d/merge", number)), payload, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.MergeCommit.Hash, nil
+}
+
 func (c *BitbucketConnector) NewProposalURL(branch, parentBranch string) (string, error) {
 	query := url.Values{}
 	branchSha, err := c.git.ShaForBranch(branch)
 	if err != nil {
 		return "", fmt.Errorf("cannot determine pull request URL from %q to %q: %w", branch, parentBranch, err)
 	}
-	query.Add("source", strings.Join([]string{c.organization + "/" + c.Repository, branchSha[0:12], branch}, ":"))
-	query.Add("dest", strings.Join([]string{c.organization + "/" + c.Repository, "", parentBranch}, ":"))
-	return fmt.Sprintf("%s/pull-request/new?%s", c.RepositoryURL(), query.Encode()), nil
+	query.Add("source", strings.Join([]string{c.originOrganization() + "/" + c.originRepository(), branchSha[0:12], branch}, ":"))
+	query.Add("dest", strings.Join([]string{c.Organization + "/" + c.Repository, "", parentBranch}, ":"))
+	// pull requests from forks get created in the fork
+	return fmt.Sprintf("https://%s/%s/%s/pull-request/new?%s", c.Hostname, c.originOrganization(), c.originRepository(), query.Encode()), nil
+}
+
+func (c *BitbucketConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	var result bitbucketCommitStatusList
+	err := c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d/statuses", number)), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]CIStatus, len(result.Values))
+	for s, status := range result.Values {
+		statuses[s] = parseBitbucketStatusState(status.State)
+	}
+	var pullRequest bitbucketPullRequest
+	err = c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number)), nil, &pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	approvals := 0
+	for _, participant := range pullRequest.Participants {
+		if participant.Approved {
+			approvals++
+		}
+	}
+	// Bitbucket provides merge requirements only to repository admins
+	return &ProposalChecks{
+		Approvals:         approvals,
+		CIStatus:          combineCIStatuses(statuses...),
+		RequiredApprovals: 0,
+		RequiredChecks:    []CheckResult{},
+	}, nil
+}
+
+func (c *BitbucketConnector) ProposalMerged(number int) (bool, error) {
+	var result bitbucketPullRequest
+	err := c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number)), nil, &result)
+	if err != nil {
+		return false, err
+	}
+	return result.State == "MERGED", nil
 }
 
 func (c *BitbucketConnector) RepositoryURL() string {
-	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.organization, c.Repository)
+	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.Organization, c.Repository)
 }
 
-//nolint:nonamedreturns
-func (c *BitbucketConnector) SquashMergeProposal(number int, message string) (mergeSHA string, err error) {
-	return "", errors.New("shipping pull requests via the Bitbucket API is currently not supported. If you need this functionality, please vote for it by opening a ticket at https://github.com/git-town/git-town/issues")
+func (c *BitbucketConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("Bitbucket API: updating description of PR #%d\n", number)
+	}
+	payload := map[string]interface{}{
+		"description": body,
+	}
+	return c.api().call(http.MethodPut, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number)), payload, nil)
 }
 
 func (c *BitbucketConnector) UpdateProposalTarget(number int, target string) error {
-	return errors.New("shipping pull requests via the Bitbucket API is currently not supported. If you need this functionality, please vote for it by opening a ticket at https://github.com/git-town/git-town/issues")
+	if c.log != nil {
+		c.log("Bitbucket API: updating destination branch for PR #%d to %q\n", number, target)
+	}
+	payload := map[string]interface{}{
+		"destination": bitbucketBranchRef{Branch: bitbucketBranch{Name: target}},
+	}
+	return c.api().call(http.MethodPut, c.repoEndpoint(fmt.Sprintf("/pullrequests/%d", number)), payload, nil)
+}
+
+// api provides a client for the repository endpoints of the Bitbucket Cloud API.
+func (c *BitbucketConnector) api() restClient {
+	return restClient{
+		authorize: func(request *http.Request) {
+			// app passwords use basic auth with the username, access tokens use bearer auth
+			if username, password, isAppPassword := strings.Cut(c.APIToken, ":"); isAppPassword {
+				request.SetBasicAuth(username, password)
+			} else {
+				bearerAuth(c.APIToken)(request)
+			}
+		},
+		serviceName: "Bitbucket",
+	}
+}
+
+// repoEndpoint provides the URL of the given path within the API endpoint for the current repository.
+func (c *BitbucketConnector) repoEndpoint(path string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s%s", c.APIURL, c.Organization, c.Repository, path)
+}
+
+// *************************************
+// Bitbucket API data structures
+// *************************************
+
+type bitbucketBranch struct {
+	Name string `json:"name"`
+}
+
+type bitbucketBranchRef struct {
+	Branch bitbucketBranch `json:"branch"`
+}
+
+type bitbucketCommit struct {
+	Hash string `json:"hash"`
+}
+
+type bitbucketCommitStatus struct {
+	State string `json:"state"`
+}
+
+type bitbucketCommitStatusList struct {
+	Values []bitbucketCommitStatus `json:"values"`
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+}
+
+type bitbucketPullRequest struct {
+	Description  string                    `json:"description"`
+	Destination  bitbucketBranchRef        `json:"destination"`
+	Draft        bool                      `json:"draft"`
+	ID           int                       `json:"id"`
+	Links        bitbucketPullRequestLinks `json:"links"`
+	MergeCommit  bitbucketCommit           `json:"merge_commit"`
+	Participants []bitbucketParticipant    `json:"participants"`
+	Source       bitbucketBranchRef        `json:"source"`
+	State        string                    `json:"state"`
+	Title        string                    `json:"title"`
+}
+
+type bitbucketParticipant struct {
+	Approved bool `json:"approved"`
+}
+
+type bitbucketPullRequestLinks struct {
+	HTML bitbucketLink `json:"html"`
+}
+
+type bitbucketPullRequestList struct {
+	Values []bitbucketPullRequest `json:"values"`
+}
+
+// bitbucketMergeStrategy provides the Bitbucket merge strategy for the given ship strategy.
+func bitbucketMergeStrategy(strategy config.ShipStrategy) (string, error) {
+	switch strategy {
+	case config.ShipStrategySquash:
+		return "squash", nil
+	case config.ShipStrategyMerge:
+		return "merge_commit", nil
+	case config.ShipStrategyFastForward:
+		return "fast_forward", nil
+	case config.ShipStrategyRebase:
+	}
+	return "", unsupportedShipStrategyError("Bitbucket", strategy)
+}
+
+// parseBitbucketPullRequest extracts standardized proposal data from the given Bitbucket pull request.
+func parseBitbucketPullRequest(pullRequest bitbucketPullRequest) Proposal {
+	return Proposal{
+		Body:            pullRequest.Description,
+		Draft:           pullRequest.Draft,
+		Number:          pullRequest.ID,
+		Target:          pullRequest.Destination.Branch.Name,
+		Title:           pullRequest.Title,
+		URL:             pullRequest.Links.HTML.Href,
+		CanMergeWithAPI: pullRequest.State == "OPEN",
+	}
+}
+
+// parseBitbucketStatusState provides the CI status for the given state of a Bitbucket commit status.
+func parseBitbucketStatusState(state string) CIStatus {
+	switch state {
+	case "SUCCESSFUL":
+		return CIStatusSuccess
+	case "INPROGRESS":
+		return CIStatusPending
+	default:
+		return CIStatusFailure
+	}
 }
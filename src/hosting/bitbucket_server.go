@@ -0,0 +1,366 @@
+package hosting
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/giturl"
+)
+
+// BitbucketServerConnector provides access to the API of self-hosted Bitbucket Server and Bitbucket Data Center installations.
+// Repositories on these installations live at "/projects/<project key>/repos/<repo slug>".
+type BitbucketServerConnector struct {
+	CommonConfig
+	// APIURL is the base URL of the Bitbucket Server REST API
+	APIURL string
+	// WebURL is the base URL of the web interface, including the context path of the installation
+	WebURL string
+	log    logFn
+}
+
+// NewBitbucketServerConnector provides a Bitbucket Server connector instance
+// if the current repo is configured to be hosted on Bitbucket Server, otherwise nil.
+func NewBitbucketServerConnector(gitConfig gitTownConfig, log logFn) (*BitbucketServerConnector, error) {
+	hostingService, err := gitConfig.HostingService()
+	if err != nil {
+		return nil, err
+	}
+	url := gitConfig.OriginURL()
+	if url == nil || hostingService != config.HostingServiceBitbucketServer {
+		return nil, nil //nolint:nilnil
+	}
+	hostname, webURL := bitbucketServerLocation(url)
+	commonConfig := newCommonConfig(gitConfig, gitConfig.APIToken(config.HostingServiceBitbucketServer, hostname).Value, hostname, url)
+	commonConfig.Organization = bitbucketServerProjectKey(commonConfig.Organization)
+	commonConfig.ForkOrganization = bitbucketServerProjectKey(commonConfig.ForkOrganization)
+	return &BitbucketServerConnector{
+		APIURL:       webURL + "/rest/api/1.0",
+		WebURL:       webURL,
+		CommonConfig: commonConfig,
+		log:          log,
+	}, nil
+}
+
+// bitbucketServerLocation provides the hostname and the base URL of the web interface
+// of the Bitbucket Server installation at the given clone URL.
+// HTTPS clone URLs look like "https://host/<context path>/scm/KEY/repo.git",
+// SSH clone URLs like "ssh://git@host:7999/KEY/repo.git" or "git@host:scm/KEY/repo.git".
+//
+//nolint:nonamedreturns  // return values aren't obvious from the function name
+func bitbucketServerLocation(url *giturl.Parts) (hostname, webURL string) {
+	host, path, _ := strings.Cut(url.Host, "/")
+	// SSH remotes of Bitbucket Server contain the port of the SSH server
+	hostname, _, _ = strings.Cut(host, ":")
+	contextPath := ""
+	if path != "" && path != "scm" {
+		contextPath = "/" + strings.TrimSuffix(path, "/scm")
+	}
+	return hostname, fmt.Sprintf("https://%s%s", hostname, contextPath)
+}
+
+// bitbucketServerProjectKey provides the key of the project with the given path in a Bitbucket Server clone URL.
+func bitbucketServerProjectKey(path string) string {
+	return strings.TrimPrefix(path, "scm/")
+}
+
+func (c *BitbucketServerConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	return unsupportedAutoMergeError("Bitbucket Server")
+}
+
+func (c *BitbucketServerConnector) CancelAutoMerge(number int) error {
+	return unsupportedAutoMergeError("Bitbucket Server")
+}
+
+func (c *BitbucketServerConnector) CloseProposal(number int) error {
+	return fmt.Errorf("closing Bitbucket Server pull requests via the API is currently not supported")
+}
+
+func (c *BitbucketServerConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	return nil, fmt.Errorf("creating Bitbucket Server pull requests via the API is currently not supported")
+}
+
+func (c *BitbucketServerConnector) DefaultProposalMessage(proposal Proposal) string {
+	return fmt.Sprintf("%s (#%d)", proposal.Title, proposal.Number)
+}
+
+func (c *BitbucketServerConnector) FindProposal(branch, target string) (*Proposal, error) {
+	query := url.Values{}
+	if c.IsFork() {
+		// the upstream repo doesn't contain the branches of forks, it receives their pull requests
+		query.Add("at", "refs/heads/"+target)
+		query.Add("direction", "INCOMING")
+	} else {
+		query.Add("at", "refs/heads/"+branch)
+		query.Add("direction", "OUTGOING")
+	}
+	query.Add("state", "OPEN")
+	var result bitbucketServerPullRequestList
+	err := c.api().call(http.MethodGet, c.repoEndpoint("/pull-requests?"+query.Encode()), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests := []bitbucketServerPullRequest{}
+	for _, pullRequest := range result.Values {
+		if pullRequest.FromRef.DisplayID == branch && pullRequest.ToRef.DisplayID == target && c.isFromOrigin(pullRequest) {
+			pullRequests = append(pullRequests, pullRequest)
+		}
+	}
+	if len(pullRequests) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	if len(pullRequests) > 1 {
+		return nil, fmt.Errorf("found %d pull requests from branch %q into branch %q", len(pullRequests), branch, target)
+	}
+	proposal := parseBitbucketServerPullRequest(pullRequests[0])
+	return &proposal, nil
+}
+
+func (c *BitbucketServerConnector) HostingServiceName() string {
+	return "Bitbucket Server"
+}
+
+//nolint:nonamedreturns
+func (c *BitbucketServerConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error) {
+	if number <= 0 {
+		return "", fmt.Errorf("no pull request number given")
+	}
+	strategyID, err := bitbucketServerMergeStrategy(strategy)
+	if err != nil {
+		return "", err
+	}
+	if c.log != nil {
+		c.log("Bitbucket Server API: merging PR #%d\n", number)
+	}
+	// Bitbucket Server rejects changes to pull requests that don't provide their current version
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return "", err
+	}
+	payload := map[string]interface{}{
+		"message":    message,
+		"strategyId": strategyID,
+	}
+	var result bitbucketServerPullRequest
+	err = c.api().call(http.MethodPost, c.repoEndpoint(fmt.Sprintf("/pull-requests/%d/merge?version=%d", number, pullRequest.Version)), payload, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.Properties.MergeCommit.ID, nil
+}
+
+func (c *BitbucketServerConnector) NewProposalURL(branch, parentBranch string) (string, error) {
+	query := url.Values{}
+	query.Add("sourceBranch", "refs/heads/"+branch)
+	query.Add("targetBranch", "refs/heads/"+parentBranch)
+	// pull requests from forks get created in the fork
+	return fmt.Sprintf("%s/projects/%s/repos/%s/pull-requests?create&%s", c.WebURL, c.originOrganization(), c.originRepository(), query.Encode()), nil
+}
+
+func (c *BitbucketServerConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return nil, err
+	}
+	// build results are provided by a separate API next to the REST API
+	buildStatusURL := fmt.Sprintf("%s/build-status/1.0/commits/%s", strings.TrimSuffix(c.APIURL, "/api/1.0"), pullRequest.FromRef.LatestCommit)
+	var result bitbucketServerBuildStatusList
+	err = c.api().call(http.MethodGet, buildStatusURL, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]CIStatus, len(result.Values))
+	for s, status := range result.Values {
+		statuses[s] = parseBitbucketStatusState(status.State)
+	}
+	approvals := 0
+	for _, reviewer := range pullRequest.Reviewers {
+		if reviewer.Approved {
+			approvals++
+		}
+	}
+	// Bitbucket Server provides merge requirements only to repository admins
+	return &ProposalChecks{
+		Approvals:         approvals,
+		CIStatus:          combineCIStatuses(statuses...),
+		RequiredApprovals: 0,
+		RequiredChecks:    []CheckResult{},
+	}, nil
+}
+
+func (c *BitbucketServerConnector) ProposalMerged(number int) (bool, error) {
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return false, err
+	}
+	return pullRequest.State == "MERGED", nil
+}
+
+func (c *BitbucketServerConnector) RepositoryURL() string {
+	return fmt.Sprintf("%s/projects/%s/repos/%s", c.WebURL, c.Organization, c.Repository)
+}
+
+func (c *BitbucketServerConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("Bitbucket Server API: updating description of PR #%d\n", number)
+	}
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{
+		"description": body,
+		"title":       pullRequest.Title,
+		"version":     pullRequest.Version,
+	}
+	return c.api().call(http.MethodPut, c.repoEndpoint(fmt.Sprintf("/pull-requests/%d", number)), payload, nil)
+}
+
+func (c *BitbucketServerConnector) UpdateProposalTarget(number int, target string) error {
+	if c.log != nil {
+		c.log("Bitbucket Server API: updating target branch for PR #%d to %q\n", number, target)
+	}
+	pullRequest, err := c.loadPullRequest(number)
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{
+		"title":   pullRequest.Title,
+		"toRef":   map[string]string{"id": "refs/heads/" + target},
+		"version": pullRequest.Version,
+	}
+	return c.api().call(http.MethodPut, c.repoEndpoint(fmt.Sprintf("/pull-requests/%d", number)), payload, nil)
+}
+
+// api provides a client for the Bitbucket Server REST API.
+func (c *BitbucketServerConnector) api() restClient {
+	return restClient{
+		authorize:   bearerAuth(c.APIToken),
+		serviceName: "Bitbucket Server",
+	}
+}
+
+// isFromOrigin indicates whether the given pull request merges a branch of the repo at the origin remote.
+func (c *BitbucketServerConnector) isFromOrigin(pullRequest bitbucketServerPullRequest) bool {
+	if !c.IsFork() {
+		// FindProposal queries only the pull requests going out of this repo
+		return true
+	}
+	repo := pullRequest.FromRef.Repository
+	return strings.EqualFold(repo.Project.Key, c.ForkOrganization) && repo.Slug == c.ForkRepository
+}
+
+// loadPullRequest provides the pull request with the given number.
+func (c *BitbucketServerConnector) loadPullRequest(number int) (*bitbucketServerPullRequest, error) {
+	var result bitbucketServerPullRequest
+	err := c.api().call(http.MethodGet, c.repoEndpoint(fmt.Sprintf("/pull-requests/%d", number)), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// repoEndpoint provides the URL of the given path within the API endpoint for the current repository.
+func (c *BitbucketServerConnector) repoEndpoint(path string) string {
+	return fmt.Sprintf("%s/projects/%s/repos/%s%s", c.APIURL, c.Organization, c.Repository, path)
+}
+
+// *************************************
+// Bitbucket Server API data structures
+// *************************************
+
+type bitbucketServerBuildStatus struct {
+	State string `json:"state"`
+}
+
+type bitbucketServerBuildStatusList struct {
+	Values []bitbucketServerBuildStatus `json:"values"`
+}
+
+type bitbucketServerCommit struct {
+	ID string `json:"id"`
+}
+
+type bitbucketServerLink struct {
+	Href string `json:"href"`
+}
+
+type bitbucketServerPullRequest struct {
+	Description string                               `json:"description"`
+	Draft       bool                                 `json:"draft"`
+	FromRef     bitbucketServerRef                   `json:"fromRef"`
+	ID          int                                  `json:"id"`
+	Links       bitbucketServerPullRequestLinks      `json:"links"`
+	Properties  bitbucketServerPullRequestProperties `json:"properties"`
+	Reviewers   []bitbucketServerReviewer            `json:"reviewers"`
+	State       string                               `json:"state"`
+	Title       string                               `json:"title"`
+	ToRef       bitbucketServerRef                   `json:"toRef"`
+	Version     int                                  `json:"version"`
+}
+
+type bitbucketServerPullRequestLinks struct {
+	Self []bitbucketServerLink `json:"self"`
+}
+
+type bitbucketServerPullRequestList struct {
+	Values []bitbucketServerPullRequest `json:"values"`
+}
+
+type bitbucketServerPullRequestProperties struct {
+	MergeCommit bitbucketServerCommit `json:"mergeCommit"`
+}
+
+type bitbucketServerReviewer struct {
+	Approved bool `json:"approved"`
+}
+
+type bitbucketServerProject struct {
+	Key string `json:"key"`
+}
+
+type bitbucketServerRef struct {
+	DisplayID    string                    `json:"displayId,omitempty"`
+	ID           string                    `json:"id"`
+	LatestCommit string                    `json:"latestCommit,omitempty"`
+	Repository   bitbucketServerRepository `json:"repository"`
+}
+
+type bitbucketServerRepository struct {
+	Project bitbucketServerProject `json:"project"`
+	Slug    string                 `json:"slug"`
+}
+
+// bitbucketServerMergeStrategy provides the ID of the Bitbucket Server merge strategy for the given ship strategy.
+func bitbucketServerMergeStrategy(strategy config.ShipStrategy) (string, error) {
+	switch strategy {
+	case config.ShipStrategySquash:
+		return "squash", nil
+	case config.ShipStrategyMerge:
+		return "no-ff", nil
+	case config.ShipStrategyRebase:
+		return "rebase-ff-only", nil
+	case config.ShipStrategyFastForward:
+		return "ff-only", nil
+	}
+	return "", unsupportedShipStrategyError("Bitbucket Server", strategy)
+}
+
+// parseBitbucketServerPullRequest extracts standardized proposal data from the given Bitbucket Server pull request.
+func parseBitbucketServerPullRequest(pullRequest bitbucketServerPullRequest) Proposal {
+	url := ""
+	if len(pullRequest.Links.Self) > 0 {
+		url = pullRequest.Links.Self[0].Href
+	}
+	return Proposal{
+		Body:            pullRequest.Description,
+		Draft:           pullRequest.Draft,
+		Number:          pullRequest.ID,
+		Target:          pullRequest.ToRef.DisplayID,
+		Title:           pullRequest.Title,
+		URL:             url,
+		CanMergeWithAPI: pullRequest.State == "OPEN",
+	}
+}
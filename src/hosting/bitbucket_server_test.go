@@ -0,0 +1,290 @@
+package hosting_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBitbucketServerConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("HTTPS remote", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "bitbucket-server",
+			originURL:      "https://bitbucket.example.com/scm/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "Bitbucket Server", connector.HostingServiceName())
+		assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo", connector.RepositoryURL())
+		assert.Equal(t, "https://bitbucket.example.com/rest/api/1.0", connector.APIURL)
+	})
+
+	t.Run("SSH remote with port", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "bitbucket-server",
+			originURL:      "ssh://git@bitbucket.example.com:7999/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo", connector.RepositoryURL())
+	})
+
+	t.Run("SSH remote with scm prefix", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "bitbucket-server",
+			originURL:      "git@bitbucket.example.com:scm/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo", connector.RepositoryURL())
+	})
+
+	t.Run("installation with context path", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "bitbucket-server",
+			originURL:      "https://example.com/bitbucket/scm/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, connector)
+		assert.Equal(t, "example.com", connector.Hostname)
+		assert.Equal(t, "https://example.com/bitbucket/projects/KEY/repos/repo", connector.RepositoryURL())
+		assert.Equal(t, "https://example.com/bitbucket/rest/api/1.0", connector.APIURL)
+	})
+
+	t.Run("fork of an upstream repository", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "bitbucket-server",
+			originURL:      "https://bitbucket.example.com/scm/~kevgo/repo.git",
+			upstreamURL:    "https://bitbucket.example.com/scm/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo", connector.RepositoryURL())
+		have, err := connector.NewProposalURL("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://bitbucket.example.com/projects/~kevgo/repos/repo/pull-requests?create&sourceBranch=refs%2Fheads%2Ffeature&targetBranch=refs%2Fheads%2Fmain", have)
+	})
+
+	t.Run("not configured as Bitbucket Server", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL: "https://bitbucket.example.com/scm/KEY/repo.git",
+		}
+		connector, err := hosting.NewBitbucketServerConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, connector)
+	})
+}
+
+func TestBitbucketServerConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("NewProposalURL", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestBitbucketServerConnector("")
+		have, err := connector.NewProposalURL("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo/pull-requests?create&sourceBranch=refs%2Fheads%2Ffeature&targetBranch=refs%2Fheads%2Fmain", have)
+	})
+
+	t.Run("FindProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/projects/KEY/repos/repo/pull-requests", r.URL.Path)
+			assert.Equal(t, "refs/heads/feature", r.URL.Query().Get("at"))
+			assert.Equal(t, "OUTGOING", r.URL.Query().Get("direction"))
+			assert.Equal(t, "OPEN", r.URL.Query().Get("state"))
+			assert.Equal(t, "Bearer apiToken", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"values": [
+				{"id": 1, "title": "into other", "state": "OPEN", "fromRef": {"displayId": "feature"}, "toRef": {"displayId": "other"}},
+				{"id": 2, "title": "my title", "description": "my body", "state": "OPEN", "fromRef": {"displayId": "feature"}, "toRef": {"displayId": "main"}, "links": {"self": [{"href": "https://bitbucket.example.com/projects/KEY/repos/repo/pull-requests/2"}]}}
+			]}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		want := &hosting.Proposal{
+			Body:            "my body",
+			Draft:           false,
+			Number:          2,
+			Target:          "main",
+			Title:           "my title",
+			URL:             "https://bitbucket.example.com/projects/KEY/repos/repo/pull-requests/2",
+			CanMergeWithAPI: true,
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("FindProposal in a fork", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/KEY/repos/repo/pull-requests", r.URL.Path)
+			assert.Equal(t, "refs/heads/main", r.URL.Query().Get("at"))
+			assert.Equal(t, "INCOMING", r.URL.Query().Get("direction"))
+			fmt.Fprint(w, `{"values": [
+				{"id": 1, "title": "other fork", "state": "OPEN", "fromRef": {"displayId": "feature", "repository": {"slug": "repo", "project": {"key": "~OTHER"}}}, "toRef": {"displayId": "main"}},
+				{"id": 2, "title": "my title", "state": "OPEN", "fromRef": {"displayId": "feature", "repository": {"slug": "repo", "project": {"key": "~KEVGO"}}}, "toRef": {"displayId": "main"}}
+			]}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		connector.ForkOrganization = "~kevgo"
+		connector.ForkRepository = "repo"
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, have.Number)
+	})
+
+	t.Run("ProposalChecks", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			switch r.URL.Path {
+			case "/projects/KEY/repos/repo/pull-requests/1":
+				fmt.Fprint(w, `{"id": 1, "version": 3, "fromRef": {"displayId": "feature", "latestCommit": "abc123"}, "reviewers": [{"approved": true}, {"approved": false}]}`)
+			case "/build-status/1.0/commits/abc123":
+				fmt.Fprint(w, `{"values": [{"state": "SUCCESSFUL"}, {"state": "INPROGRESS"}]}`)
+			default:
+				t.Errorf("unexpected request to %q", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		have, err := connector.ProposalChecks(1)
+		assert.NoError(t, err)
+		want := &hosting.ProposalChecks{
+			Approvals:         1,
+			CIStatus:          hosting.CIStatusPending,
+			RequiredApprovals: 0,
+			RequiredChecks:    []hosting.CheckResult{},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("MergeProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				assert.Equal(t, "/projects/KEY/repos/repo/pull-requests/1", r.URL.Path)
+				fmt.Fprint(w, `{"id": 1, "title": "my title", "version": 3}`)
+			case http.MethodPost:
+				assert.Equal(t, "/projects/KEY/repos/repo/pull-requests/1/merge", r.URL.Path)
+				assert.Equal(t, "3", r.URL.Query().Get("version"))
+				var payload map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "title\n\nbody", payload["message"])
+				assert.Equal(t, "squash", payload["strategyId"])
+				fmt.Fprint(w, `{"id": 1, "state": "MERGED", "properties": {"mergeCommit": {"id": "abc123"}}}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategySquash, "title\n\nbody")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+	})
+
+	t.Run("MergeProposal with fast-forward", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"id": 1, "title": "my title", "version": 3}`)
+			case http.MethodPost:
+				var payload map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "ff-only", payload["strategyId"])
+				fmt.Fprint(w, `{"id": 1, "state": "MERGED", "properties": {"mergeCommit": {"id": "abc123"}}}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategyFastForward, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+	})
+
+	t.Run("UpdateProposalBody", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/KEY/repos/repo/pull-requests/2", r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"id": 2, "title": "my title", "version": 5}`)
+			case http.MethodPut:
+				var payload map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "new body", payload["description"])
+				assert.Equal(t, "my title", payload["title"])
+				assert.Equal(t, float64(5), payload["version"])
+				fmt.Fprint(w, `{"id": 2}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		err := connector.UpdateProposalBody(2, "new body")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/KEY/repos/repo/pull-requests/2", r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"id": 2, "title": "my title", "version": 5}`)
+			case http.MethodPut:
+				var payload map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+				assert.Equal(t, "my title", payload["title"])
+				assert.Equal(t, float64(5), payload["version"])
+				assert.Equal(t, map[string]interface{}{"id": "refs/heads/main"}, payload["toRef"])
+				fmt.Fprint(w, `{"id": 2}`)
+			default:
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		}))
+		defer server.Close()
+		connector := newTestBitbucketServerConnector(server.URL)
+		err := connector.UpdateProposalTarget(2, "main")
+		assert.NoError(t, err)
+	})
+}
+
+func newTestBitbucketServerConnector(apiURL string) *hosting.BitbucketServerConnector {
+	return &hosting.BitbucketServerConnector{ //nolint:exhaustruct
+		APIURL: apiURL,
+		WebURL: "https://bitbucket.example.com",
+		CommonConfig: hosting.CommonConfig{ //nolint:exhaustruct
+			APIToken:     "apiToken",
+			Hostname:     "bitbucket.example.com",
+			Organization: "KEY",
+			Repository:   "repo",
+		},
+	}
+}
@@ -1,8 +1,14 @@
 package hosting_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/stretchr/testify/assert"
 )
@@ -15,7 +21,7 @@ func TestNewBitbucketConnector(t *testing.T) {
 			hostingService: "bitbucket",
 			originURL:      "git@self-hosted-bitbucket.com:git-town/git-town.git",
 		}
-		connector, err := hosting.NewBitbucketConnector(repoConfig, nil)
+		connector, err := hosting.NewBitbucketConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "Bitbucket", connector.HostingServiceName())
@@ -28,7 +34,7 @@ func TestNewBitbucketConnector(t *testing.T) {
 			originURL:      "git@my-ssh-identity.com:git-town/git-town.git",
 			originOverride: "bitbucket.org",
 		}
-		connector, err := hosting.NewBitbucketConnector(repoConfig, nil)
+		connector, err := hosting.NewBitbucketConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "Bitbucket", connector.HostingServiceName())
@@ -41,10 +47,260 @@ func TestNewBitbucketConnector(t *testing.T) {
 			hostingService: "bitbucket",
 			originURL:      "username@bitbucket.org:git-town/git-town.git",
 		}
-		connector, err := hosting.NewBitbucketConnector(repoConfig, nil)
+		connector, err := hosting.NewBitbucketConnector(repoConfig, nil, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, connector)
 		assert.Equal(t, "Bitbucket", connector.HostingServiceName())
 		assert.Equal(t, "https://bitbucket.org/git-town/git-town", connector.RepositoryURL())
 	})
+
+	t.Run("fork of an upstream repository", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL:   "git@bitbucket.org:kevgo/git-town.git",
+			upstreamURL: "git@bitbucket.org:git-town/git-town.git",
+		}
+		connector, err := hosting.NewBitbucketConnector(repoConfig, nil, nil)
+		assert.Nil(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://bitbucket.org/git-town/git-town", connector.RepositoryURL())
+	})
+}
+
+func TestBitbucketConnector(t *testing.T) {
+	t.Parallel()
+	t.Run("FindProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/repositories/git-town/git-town/pullrequests", r.URL.Path)
+			assert.Equal(t, `source.branch.name = "feature" AND destination.branch.name = "main" AND state = "OPEN"`, r.URL.Query().Get("q"))
+			assert.Equal(t, "Bearer apiToken", r.Header.Get("Authorization"))
+			fmt.Fprint(w, `{"values": [{"id": 1, "title": "my title", "description": "my body", "state": "OPEN", "destination": {"branch": {"name": "main"}}, "links": {"html": {"href": "https://bitbucket.org/git-town/git-town/pull-requests/1"}}}]}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		want := &hosting.Proposal{
+			Body:            "my body",
+			Draft:           false,
+			Number:          1,
+			Target:          "main",
+			Title:           "my title",
+			URL:             "https://bitbucket.org/git-town/git-town/pull-requests/1",
+			CanMergeWithAPI: true,
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("FindProposal in a fork", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repositories/git-town/git-town/pullrequests", r.URL.Path)
+			assert.Equal(t, `source.branch.name = "feature" AND destination.branch.name = "main" AND state = "OPEN" AND source.repository.full_name = "kevgo/git-town"`, r.URL.Query().Get("q"))
+			fmt.Fprint(w, `{"values": [{"id": 1, "title": "my title", "state": "OPEN", "destination": {"branch": {"name": "main"}}}]}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		connector.ForkOrganization = "kevgo"
+		connector.ForkRepository = "git-town"
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, have.Number)
+	})
+
+	t.Run("FindProposal without matching pull requests", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"values": []}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Nil(t, have)
+	})
+
+	t.Run("FindProposal with API error", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		_, err := connector.FindProposal("feature", "main")
+		assert.Error(t, err)
+	})
+
+	t.Run("FindProposal without API token", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request to %q", r.URL.Path)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		connector.APIToken = ""
+		have, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+		assert.Nil(t, have)
+	})
+
+	t.Run("ProposalChecks", func(t *testing.T) {
+		t.Parallel()
+		tests := map[string]struct {
+			statuses string
+			want     hosting.CIStatus
+		}{
+			"no statuses":    {`[]`, hosting.CIStatusNone},
+			"all successful": {`[{"state": "SUCCESSFUL"}, {"state": "SUCCESSFUL"}]`, hosting.CIStatusSuccess},
+			"in progress":    {`[{"state": "SUCCESSFUL"}, {"state": "INPROGRESS"}]`, hosting.CIStatusPending},
+			"failed":         {`[{"state": "INPROGRESS"}, {"state": "FAILED"}]`, hosting.CIStatusFailure},
+			"stopped":        {`[{"state": "STOPPED"}]`, hosting.CIStatusFailure},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, http.MethodGet, r.Method)
+					switch r.URL.Path {
+					case "/repositories/git-town/git-town/pullrequests/1/statuses":
+						fmt.Fprintf(w, `{"values": %s}`, test.statuses)
+					case "/repositories/git-town/git-town/pullrequests/1":
+						fmt.Fprint(w, `{"id": 1, "state": "OPEN", "participants": [{"approved": true}, {"approved": false}]}`)
+					default:
+						t.Errorf("unexpected request to %q", r.URL.Path)
+					}
+				}))
+				defer server.Close()
+				connector := newTestBitbucketConnector(server.URL)
+				have, err := connector.ProposalChecks(1)
+				assert.NoError(t, err)
+				want := &hosting.ProposalChecks{
+					Approvals:         1,
+					CIStatus:          test.want,
+					RequiredApprovals: 0,
+					RequiredChecks:    []hosting.CheckResult{},
+				}
+				assert.Equal(t, want, have)
+			})
+		}
+	})
+
+	t.Run("MergeProposal", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/repositories/git-town/git-town/pullrequests/1/merge", r.URL.Path)
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, "title\n\nbody", payload["message"])
+			assert.Equal(t, "squash", payload["merge_strategy"])
+			assert.Equal(t, false, payload["close_source_branch"])
+			fmt.Fprint(w, `{"id": 1, "state": "MERGED", "merge_commit": {"hash": "abc123"}}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategySquash, "title\n\nbody")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+	})
+
+	t.Run("MergeProposal with merge commit", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			assert.Equal(t, "merge_commit", payload["merge_strategy"])
+			fmt.Fprint(w, `{"id": 1, "state": "MERGED", "merge_commit": {"hash": "abc123"}}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		have, err := connector.MergeProposal(1, config.ShipStrategyMerge, "title\n\nbody")
+		assert.NoError(t, err)
+		assert.Equal(t, "abc123", have)
+	})
+
+	t.Run("MergeProposal with unsupported strategy", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestBitbucketConnector("")
+		_, err := connector.MergeProposal(1, config.ShipStrategyRebase, "message")
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeProposal without pull request number", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestBitbucketConnector("")
+		_, err := connector.MergeProposal(0, config.ShipStrategySquash, "message")
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeProposal without API token", func(t *testing.T) {
+		t.Parallel()
+		connector := newTestBitbucketConnector("")
+		connector.APIToken = ""
+		_, err := connector.MergeProposal(1, config.ShipStrategySquash, "message")
+		assert.Error(t, err)
+	})
+
+	t.Run("UpdateProposalBody", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/repositories/git-town/git-town/pullrequests/2", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"description": "new body"}`, string(body))
+			fmt.Fprint(w, `{"id": 2}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		err := connector.UpdateProposalBody(2, "new body")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/repositories/git-town/git-town/pullrequests/2", r.URL.Path)
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"destination": {"branch": {"name": "main"}}}`, string(body))
+			fmt.Fprint(w, `{"id": 2}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		err := connector.UpdateProposalTarget(2, "main")
+		assert.NoError(t, err)
+	})
+
+	t.Run("app password authentication", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "username", username)
+			assert.Equal(t, "appPassword", password)
+			fmt.Fprint(w, `{"values": []}`)
+		}))
+		defer server.Close()
+		connector := newTestBitbucketConnector(server.URL)
+		connector.APIToken = "username:appPassword"
+		_, err := connector.FindProposal("feature", "main")
+		assert.NoError(t, err)
+	})
+}
+
+func newTestBitbucketConnector(apiURL string) *hosting.BitbucketConnector {
+	return &hosting.BitbucketConnector{ //nolint:exhaustruct
+		APIURL: apiURL,
+		CommonConfig: hosting.CommonConfig{ //nolint:exhaustruct
+			APIToken:     "apiToken",
+			Hostname:     "bitbucket.org",
+			Organization: "git-town",
+			Repository:   "git-town",
+		},
+	}
 }
@@ -0,0 +1,95 @@
+package hosting
+
+import "fmt"
+
+// CIStatus describes the combined result of the CI jobs that run for a proposal.
+type CIStatus string
+
+const (
+	CIStatusNone    CIStatus = ""        // no CI jobs run for the proposal
+	CIStatusPending CIStatus = "pending" // at least one CI job hasn't finished yet
+	CIStatusSuccess CIStatus = "success" // all CI jobs have passed
+	CIStatusFailure CIStatus = "failure" // at least one CI job has failed
+)
+
+// CheckResult contains the result of an individual automated check for a proposal.
+type CheckResult struct {
+	// name of the check, for example the name of the CI job
+	Name string
+
+	// result of the check, CIStatusNone if the check hasn't reported a result for the proposal
+	Status CIStatus
+}
+
+// ProposalChecks contains the results of the automated checks and reviews for a proposal.
+type ProposalChecks struct {
+	// number of reviewers who have approved the proposal
+	Approvals int
+
+	// combined status of all CI jobs for the latest commit of the proposal
+	CIStatus CIStatus
+
+	// number of approvals that the target branch requires, 0 if it doesn't require any or Git Town cannot determine it
+	RequiredApprovals int
+
+	// results of the checks that the target branch requires to pass before merging
+	RequiredChecks []CheckResult
+}
+
+// Problems describes why the proposal isn't ready to ship yet.
+// Returns an empty list if the proposal is ready.
+func (pc ProposalChecks) Problems() []string {
+	result := []string{}
+	switch pc.CIStatus {
+	case CIStatusFailure:
+		result = append(result, "CI failed")
+	case CIStatusPending:
+		result = append(result, "CI is still running")
+	case CIStatusSuccess, CIStatusNone:
+	}
+	for _, check := range pc.RequiredChecks {
+		switch check.Status {
+		case CIStatusFailure:
+			result = append(result, fmt.Sprintf("the required check %q failed", check.Name))
+		case CIStatusPending:
+			result = append(result, fmt.Sprintf("the required check %q is still running", check.Name))
+		case CIStatusNone:
+			result = append(result, fmt.Sprintf("the required check %q hasn't run", check.Name))
+		case CIStatusSuccess:
+		}
+	}
+	if pc.Approvals < pc.RequiredApprovals {
+		result = append(result, fmt.Sprintf("it has %d of %d required approvals", pc.Approvals, pc.RequiredApprovals))
+	}
+	return result
+}
+
+// combineCIStatuses provides the overall status of CI jobs with the given individual statuses.
+// A failing job fails the whole build, otherwise unfinished jobs make the build pending.
+func combineCIStatuses(statuses ...CIStatus) CIStatus {
+	result := CIStatusNone
+	for _, status := range statuses {
+		switch status {
+		case CIStatusFailure:
+			return CIStatusFailure
+		case CIStatusPending:
+			result = CIStatusPending
+		case CIStatusSuccess:
+			if result == CIStatusNone {
+				result = CIStatusSuccess
+			}
+		case CIStatusNone:
+		}
+	}
+	return result
+}
+
+// requiredCheckResults provides the results of the checks with the given names
+// given the results of all checks by name.
+func requiredCheckResults(names []string, results map[string]CIStatus) []CheckResult {
+	checks := []CheckResult{}
+	for _, name := range names {
+		checks = append(checks, CheckResult{Name: name, Status: results[name]})
+	}
+	return checks
+}
@@ -0,0 +1,51 @@
+package hosting_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProposalChecks(t *testing.T) {
+	t.Parallel()
+	t.Run(".Problems()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("ready to ship", func(t *testing.T) {
+			t.Parallel()
+			checks := hosting.ProposalChecks{
+				Approvals:         2,
+				CIStatus:          hosting.CIStatusSuccess,
+				RequiredApprovals: 1,
+				RequiredChecks:    []hosting.CheckResult{{Name: "test", Status: hosting.CIStatusSuccess}},
+			}
+			assert.Equal(t, []string{}, checks.Problems())
+		})
+		t.Run("no CI and no requirements", func(t *testing.T) {
+			t.Parallel()
+			checks := hosting.ProposalChecks{} //nolint:exhaustruct
+			assert.Equal(t, []string{}, checks.Problems())
+		})
+		t.Run("failing checks and missing approvals", func(t *testing.T) {
+			t.Parallel()
+			checks := hosting.ProposalChecks{
+				Approvals:         1,
+				CIStatus:          hosting.CIStatusFailure,
+				RequiredApprovals: 2,
+				RequiredChecks: []hosting.CheckResult{
+					{Name: "lint", Status: hosting.CIStatusFailure},
+					{Name: "test", Status: hosting.CIStatusPending},
+					{Name: "deploy", Status: hosting.CIStatusNone},
+				},
+			}
+			want := []string{
+				"CI failed",
+				`the required check "lint" failed`,
+				`the required check "test" is still running`,
+				`the required check "deploy" hasn't run`,
+				"it has 1 of 2 required approvals",
+			}
+			assert.Equal(t, want, checks.Problems())
+		})
+	})
+}
@@ -1,11 +1,12 @@
 // Package hosting provides support for interacting with code hosting services.
 // Commands like "new-pull-request", "repo", and "ship" use this package
-// to know how to perform Git Town operations on GitHub, Gitlab, Bitbucket, etc.
+// to know how to perform Git Town operations on GitHub, Gitlab, Bitbucket, Azure DevOps, etc.
 // Implementations of connectors for particular code hosting platforms conform to the Connector interface.
 package hosting
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/giturl"
@@ -15,6 +16,24 @@ import (
 // Individual implementations exist to talk to specific hosting platforms.
 // They all conform to this interface.
 type Connector interface {
+	// AutoMergeProposal makes the code hosting service merge the proposal with the given number using the given strategy
+	// as soon as all its requirements like passing CI are met.
+	// If the target branch uses a merge queue or merge train, it adds the proposal to it instead.
+	// The given commit message applies to strategies that create a new commit.
+	AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error
+
+	// CancelAutoMerge stops the code hosting service from merging the proposal with the given number automatically
+	// and removes it from the merge queue or merge train.
+	CancelAutoMerge(number int) error
+
+	// CloseProposal closes the proposal with the given number without merging it.
+	CloseProposal(number int) error
+
+	// CreateProposal creates a new proposal for the given branch into the given target branch
+	// and provides the created proposal.
+	// Draft proposals are marked as not ready for review.
+	CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error)
+
 	// DefaultProposalMessage provides the text that the form for creating new proposals
 	// on the respective hosting platform is prepopulated with.
 	DefaultProposalMessage(proposal Proposal) string
@@ -27,17 +46,30 @@ type Connector interface {
 	// supported by the respective connector implementation.
 	HostingServiceName() string
 
-	// SquashMergeProposal squash-merges the proposal with the given number
-	// using the given commit message.
-	SquashMergeProposal(number int, message string) (mergeSHA string, err error)
+	// IsFork indicates whether the origin remote is a fork of the repository that contains the proposals.
+	IsFork() bool
+
+	// MergeProposal merges the proposal with the given number using the given strategy
+	// and provides the SHA of the resulting commit on the target branch.
+	// The given commit message applies to strategies that create a new commit.
+	MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error)
 
 	// NewProposalURL provides the URL of the page
 	// to create a new proposal online.
 	NewProposalURL(branch, parentBranch string) (string, error)
 
+	// ProposalChecks provides the results of the automated checks for the proposal with the given number.
+	ProposalChecks(number int) (*ProposalChecks, error)
+
+	// ProposalMerged indicates whether the proposal with the given number has been merged.
+	ProposalMerged(number int) (bool, error)
+
 	// RepositoryURL provides the URL where the current repository can be found online.
 	RepositoryURL() string
 
+	// UpdateProposalBody replaces the description of the given proposal.
+	UpdateProposalBody(number int, body string) error
+
 	// UpdateProposalTarget updates the target branch of the given proposal.
 	UpdateProposalTarget(number int, target string) error
 }
@@ -50,17 +82,79 @@ type CommonConfig struct {
 	// Hostname override
 	Hostname string
 
-	// the Organization within the hosting platform that owns the repo
+	// the Organization within the hosting platform that owns the repo containing the proposals,
+	// i.e. the upstream repo if the origin remote is a fork
 	Organization string
 
 	// repo name within the organization
 	Repository string
+
+	// the organization that owns the fork at the origin remote,
+	// empty if the origin remote isn't a fork of the upstream repo
+	ForkOrganization string
+
+	// name of the fork at the origin remote,
+	// empty if the origin remote isn't a fork of the upstream repo
+	ForkRepository string
+}
+
+// IsFork indicates whether the origin remote is a fork of the repository that contains the proposals.
+func (c CommonConfig) IsFork() bool {
+	return c.ForkOrganization != ""
+}
+
+// originOrganization provides the organization that owns the repo at the origin remote.
+func (c CommonConfig) originOrganization() string {
+	if c.IsFork() {
+		return c.ForkOrganization
+	}
+	return c.Organization
+}
+
+// originRepository provides the name of the repo at the origin remote.
+func (c CommonConfig) originRepository() string {
+	if c.IsFork() {
+		return c.ForkRepository
+	}
+	return c.Repository
+}
+
+// newCommonConfig provides the CommonConfig for the repo at the given origin URL.
+// If the origin remote is a fork of the repo at the upstream remote on the same host,
+// proposals live in the upstream repo and merge branches from the fork.
+func newCommonConfig(gitConfig gitTownConfig, apiToken, hostname string, origin *giturl.Parts) CommonConfig {
+	result := CommonConfig{
+		APIToken:         apiToken,
+		Hostname:         hostname,
+		Organization:     origin.Org,
+		Repository:       origin.Repo,
+		ForkOrganization: "",
+		ForkRepository:   "",
+	}
+	upstream := gitConfig.UpstreamURL()
+	if upstream == nil || upstream.Host == "" || upstream.Host != origin.Host {
+		return result
+	}
+	if upstream.Org == origin.Org && upstream.Repo == origin.Repo {
+		return result
+	}
+	result.ForkOrganization = origin.Org
+	result.ForkRepository = origin.Repo
+	result.Organization = upstream.Org
+	result.Repository = upstream.Repo
+	return result
 }
 
 // Proposal contains information about a change request
 // on a code hosting platform.
 // Alternative names are "pull request" or "merge request".
 type Proposal struct {
+	// textual description of the proposal
+	Body string
+
+	// whether this proposal is marked as not ready for review
+	Draft bool
+
 	// the number used to identify the proposal on the hosting platform
 	Number int
 
@@ -70,6 +164,9 @@ type Proposal struct {
 	// textual title of the proposal
 	Title string
 
+	// URL of the web page of this proposal
+	URL string
+
 	// whether this proposal can be merged via the API
 	CanMergeWithAPI bool
 }
@@ -83,20 +180,20 @@ type gitTownConfig interface {
 	// HostingService provides the name of the hosting service that runs at the origin remote.
 	HostingService() (config.HostingService, error)
 
-	// GiteaToken provides the personal access token for Gitea stored in the Git configuration.
-	GiteaToken() string
+	// APIToken provides the API token for the given code hosting service running at the given hostname.
+	APIToken(service config.HostingService, hostname string) config.Token
 
-	// GitHubToken provides the personal access token for GitHub stored in the Git configuration.
-	GitHubToken() string
-
-	// GitLabToken provides the personal access token for GitLab stored in the Git configuration.
-	GitLabToken() string
+	// GitHubAPIURL provides the base URL of the GitHub API stored in the Git configuration.
+	GitHubAPIURL() string
 
 	// MainBranch provides the name of the main branch.
 	MainBranch() string
 
 	// OriginURL provides the URL of the origin remote.
 	OriginURL() *giturl.Parts
+
+	// UpstreamURL provides the URL of the upstream remote, or nil if there is none.
+	UpstreamURL() *giturl.Parts
 }
 
 // runner defines the runner methods used by the hosting package.
@@ -125,13 +222,20 @@ func NewConnector(config gitTownConfig, git gitRunner, log logFn) (Connector, er
 	if gitlabConnector != nil {
 		return gitlabConnector, nil
 	}
-	bitbucketConnector, err := NewBitbucketConnector(config, git)
+	bitbucketConnector, err := NewBitbucketConnector(config, git, log)
 	if err != nil {
 		return nil, err
 	}
 	if bitbucketConnector != nil {
 		return bitbucketConnector, nil
 	}
+	bitbucketServerConnector, err := NewBitbucketServerConnector(config, log)
+	if err != nil {
+		return nil, err
+	}
+	if bitbucketServerConnector != nil {
+		return bitbucketServerConnector, nil
+	}
 	giteaConnector, err := NewGiteaConnector(config, log)
 	if err != nil {
 		return nil, err
@@ -139,15 +243,59 @@ func NewConnector(config gitTownConfig, git gitRunner, log logFn) (Connector, er
 	if giteaConnector != nil {
 		return giteaConnector, nil
 	}
+	azureConnector, err := NewAzureConnector(config, log)
+	if err != nil {
+		return nil, err
+	}
+	if azureConnector != nil {
+		return azureConnector, nil
+	}
 	return nil, nil //nolint:nilnil  // "nil, nil" is a legitimate return value here
 }
 
+// OriginHost provides the code hosting service that hosts the origin remote and the hostname of its API,
+// or HostingServiceNone if Git Town doesn't know the code hosting service.
+func OriginHost(gitConfig gitTownConfig, git gitRunner) (config.HostingService, string, error) {
+	connector, err := NewConnector(gitConfig, git, nil)
+	if err != nil {
+		return config.HostingServiceNone, "", err
+	}
+	switch c := connector.(type) {
+	case *AzureConnector:
+		return config.HostingServiceAzure, c.Hostname, nil
+	case *BitbucketConnector:
+		return config.HostingServiceBitbucket, c.Hostname, nil
+	case *BitbucketServerConnector:
+		return config.HostingServiceBitbucketServer, c.Hostname, nil
+	case *GitHubConnector:
+		return config.HostingServiceGitHub, c.Hostname, nil
+	case *GitLabConnector:
+		return config.HostingServiceGitLab, c.Hostname, nil
+	case *GiteaConnector:
+		return config.HostingServiceGitea, c.Hostname, nil
+	}
+	return config.HostingServiceNone, "", nil
+}
+
+// unsupportedShipStrategyError communicates that the given code hosting service cannot merge proposals
+// using the given ship strategy.
+func unsupportedShipStrategyError(serviceName string, strategy config.ShipStrategy) error {
+	return fmt.Errorf("the %s API doesn't support shipping with the %q strategy, please choose another ship strategy", serviceName, strategy)
+}
+
+// unsupportedAutoMergeError communicates that the given code hosting service cannot merge proposals automatically.
+func unsupportedAutoMergeError(serviceName string) error {
+	return fmt.Errorf("the %s API doesn't support merging proposals automatically, please ship without the --auto flag", serviceName)
+}
+
 // UnsupportedServiceError communicates that the origin remote runs an unknown code hosting service.
 func UnsupportedServiceError() error {
 	return errors.New(`unsupported hosting service
 
 This command requires hosting on one of these services:
+* Azure DevOps
 * Bitbucket
+* Bitbucket Server
 * GitHub
 * GitLab
 * Gitea`)
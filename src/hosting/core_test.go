@@ -6,25 +6,21 @@ import (
 )
 
 type mockRepoConfig struct {
-	giteaToken     string                `exhaustruct:"optional"`
-	gitHubToken    string                `exhaustruct:"optional"`
-	gitLabToken    string                `exhaustruct:"optional"`
-	hostingService config.HostingService `exhaustruct:"optional"`
-	mainBranch     string                `exhaustruct:"optional"`
-	originOverride string                `exhaustruct:"optional"`
+	apiTokens      map[config.HostingService]string `exhaustruct:"optional"`
+	gitHubAPIURL   string                           `exhaustruct:"optional"`
+	hostingService config.HostingService            `exhaustruct:"optional"`
+	mainBranch     string                           `exhaustruct:"optional"`
+	originOverride string                           `exhaustruct:"optional"`
 	originURL      string
+	upstreamURL    string `exhaustruct:"optional"`
 }
 
-func (mc mockRepoConfig) GiteaToken() string {
-	return mc.giteaToken
+func (mc mockRepoConfig) APIToken(service config.HostingService, hostname string) config.Token {
+	return config.Token{Source: "mock", Value: mc.apiTokens[service]}
 }
 
-func (mc mockRepoConfig) GitHubToken() string {
-	return mc.gitHubToken
-}
-
-func (mc mockRepoConfig) GitLabToken() string {
-	return mc.gitLabToken
+func (mc mockRepoConfig) GitHubAPIURL() string {
+	return mc.gitHubAPIURL
 }
 
 func (mc mockRepoConfig) HostingService() (config.HostingService, error) {
@@ -46,3 +42,10 @@ func (mc mockRepoConfig) OriginURL() *giturl.Parts {
 	}
 	return url
 }
+
+func (mc mockRepoConfig) UpstreamURL() *giturl.Parts {
+	if mc.upstreamURL == "" {
+		return nil
+	}
+	return giturl.Parse(mc.upstreamURL)
+}
@@ -0,0 +1,92 @@
+package hosting
+
+import "github.com/git-town/git-town/v7/src/config"
+
+// dryRunConnector is a Connector that prints the changes it would make on the code hosting service
+// instead of making them.
+// Queries are forwarded to the wrapped Connector.
+// It finds the proposals it pretended to create so that later steps can work with them.
+type dryRunConnector struct {
+	Connector
+	created map[dryRunProposalKey]Proposal
+	log     logFn
+}
+
+// dryRunProposalKey identifies the proposals that a dryRunConnector pretended to create.
+type dryRunProposalKey struct {
+	branch string
+	target string
+}
+
+// NewDryRunConnector provides a Connector that forwards queries to the given Connector
+// and prints changes via the given log function instead of making them.
+//
+//nolint:ireturn
+func NewDryRunConnector(connector Connector, log logFn) Connector {
+	if _, isDryRun := connector.(dryRunConnector); isDryRun {
+		return connector
+	}
+	return dryRunConnector{Connector: connector, created: map[dryRunProposalKey]Proposal{}, log: log}
+}
+
+func (c dryRunConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	c.log("%s API: merging proposal #%d automatically with the %q strategy (dry run)\n", c.HostingServiceName(), number, strategy)
+	return nil
+}
+
+func (c dryRunConnector) CancelAutoMerge(number int) error {
+	c.log("%s API: canceling the automatic merge of proposal #%d (dry run)\n", c.HostingServiceName(), number)
+	return nil
+}
+
+func (c dryRunConnector) CloseProposal(number int) error {
+	c.log("%s API: closing proposal #%d (dry run)\n", c.HostingServiceName(), number)
+	return nil
+}
+
+func (c dryRunConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	c.log("%s API: creating proposal from %q into %q (dry run)\n", c.HostingServiceName(), branch, target)
+	url, err := c.NewProposalURL(branch, target)
+	if err != nil {
+		return nil, err
+	}
+	proposal := Proposal{
+		Body:            body,
+		CanMergeWithAPI: false,
+		Draft:           draft,
+		Number:          0,
+		Target:          target,
+		Title:           title,
+		URL:             url,
+	}
+	c.created[dryRunProposalKey{branch: branch, target: target}] = proposal
+	return &proposal, nil
+}
+
+func (c dryRunConnector) FindProposal(branch, target string) (*Proposal, error) {
+	proposal, isCreated := c.created[dryRunProposalKey{branch: branch, target: target}]
+	if isCreated {
+		return &proposal, nil
+	}
+	return c.Connector.FindProposal(branch, target)
+}
+
+func (c dryRunConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (string, error) {
+	c.log("%s API: merging proposal #%d with the %q strategy (dry run)\n", c.HostingServiceName(), number, strategy)
+	return "", nil
+}
+
+func (c dryRunConnector) UpdateProposalBody(number int, body string) error {
+	if number == 0 {
+		// proposals created in this dry run don't have a number
+		c.log("%s API: updating description of the new proposal (dry run)\n", c.HostingServiceName())
+		return nil
+	}
+	c.log("%s API: updating description of proposal #%d (dry run)\n", c.HostingServiceName(), number)
+	return nil
+}
+
+func (c dryRunConnector) UpdateProposalTarget(number int, target string) error {
+	c.log("%s API: updating target branch of proposal #%d to %q (dry run)\n", c.HostingServiceName(), number, target)
+	return nil
+}
@@ -0,0 +1,95 @@
+package hosting_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRunConnector(t *testing.T) {
+	t.Parallel()
+	newConnector := func(messages *[]string) hosting.Connector {
+		repoConfig := mockRepoConfig{
+			originURL: "git@github.com:git-town/git-town.git",
+		}
+		githubConnector, err := hosting.NewGithubConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		return hosting.NewDryRunConnector(githubConnector, func(format string, args ...interface{}) {
+			*messages = append(*messages, fmt.Sprintf(format, args...))
+		})
+	}
+
+	t.Run("AutoMergeProposal", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		err := connector.AutoMergeProposal(1, config.ShipStrategySquash, "message")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GitHub API: merging proposal #1 automatically with the \"squash\" strategy (dry run)\n"}, messages)
+	})
+
+	t.Run("CancelAutoMerge", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		err := connector.CancelAutoMerge(1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GitHub API: canceling the automatic merge of proposal #1 (dry run)\n"}, messages)
+	})
+
+	t.Run("MergeProposal", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		sha, err := connector.MergeProposal(1, config.ShipStrategyMerge, "message")
+		assert.Nil(t, err)
+		assert.Equal(t, "", sha)
+		assert.Equal(t, []string{"GitHub API: merging proposal #1 with the \"merge\" strategy (dry run)\n"}, messages)
+	})
+
+	t.Run("UpdateProposalTarget", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		err := connector.UpdateProposalTarget(1, "main")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GitHub API: updating target branch of proposal #1 to \"main\" (dry run)\n"}, messages)
+	})
+
+	t.Run("CreateProposal", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		proposal, err := connector.CreateProposal("feature", "main", "title", "body", false)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.com/git-town/git-town/compare/main...feature?expand=1", proposal.URL)
+		assert.Equal(t, []string{"GitHub API: creating proposal from \"feature\" into \"main\" (dry run)\n"}, messages)
+	})
+
+	t.Run("FindProposal finds created proposals", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := newConnector(&messages)
+		_, err := connector.CreateProposal("feature", "main", "title", "body", false)
+		assert.Nil(t, err)
+		proposal, err := connector.FindProposal("feature", "main")
+		assert.Nil(t, err)
+		assert.NotNil(t, proposal)
+		assert.Equal(t, "title", proposal.Title)
+		err = connector.UpdateProposalBody(proposal.Number, "new body")
+		assert.Nil(t, err)
+		assert.Equal(t, "GitHub API: updating description of the new proposal (dry run)\n", messages[1])
+	})
+
+	t.Run("wrapping a dry-run connector", func(t *testing.T) {
+		t.Parallel()
+		messages := []string{}
+		connector := hosting.NewDryRunConnector(newConnector(&messages), nil)
+		err := connector.CloseProposal(1)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"GitHub API: closing proposal #1 (dry run)\n"}, messages)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/git-town/git-town/v7/src/config"
@@ -16,6 +17,53 @@ type GiteaConnector struct {
 	log logFn
 }
 
+func (c *GiteaConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	return unsupportedAutoMergeError("Gitea")
+}
+
+func (c *GiteaConnector) CancelAutoMerge(number int) error {
+	return unsupportedAutoMergeError("Gitea")
+}
+
+func (c *GiteaConnector) CloseProposal(number int) error {
+	if c.log != nil {
+		c.log("Gitea API: Closing PR #%d\n", number)
+	}
+	// the Gitea API overwrites the title and body of the pull request with the given values
+	pullRequest, err := c.client.GetPullRequest(c.Organization, c.Repository, int64(number))
+	if err != nil {
+		return err
+	}
+	closed := gitea.StateClosed
+	_, err = c.client.EditPullRequest(c.Organization, c.Repository, int64(number), gitea.EditPullRequestOption{
+		Title: pullRequest.Title,
+		Body:  pullRequest.Body,
+		State: &closed,
+	})
+	return err
+}
+
+func (c *GiteaConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	if c.log != nil {
+		c.log("Gitea API: Creating PR from %q into %q\n", branch, target)
+	}
+	if draft {
+		// Gitea marks pull requests as work in progress via this title prefix
+		title = "WIP: " + title
+	}
+	pullRequest, err := c.client.CreatePullRequest(c.Organization, c.Repository, gitea.CreatePullRequestOption{
+		Head:  c.head(branch),
+		Base:  target,
+		Title: title,
+		Body:  body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	proposal := parseGiteaPullRequest(pullRequest)
+	return &proposal, nil
+}
+
 func (c *GiteaConnector) FindProposal(branch, target string) (*Proposal, error) {
 	openPullRequests, err := c.client.ListRepoPullRequests(c.Organization, c.Repository, gitea.ListPullRequestsOptions{
 		ListOptions: gitea.ListOptions{
@@ -26,20 +74,15 @@ func (c *GiteaConnector) FindProposal(branch, target string) (*Proposal, error)
 	if err != nil {
 		return nil, err
 	}
-	pullRequests := FilterGiteaPullRequests(openPullRequests, c.Organization, branch, target)
+	pullRequests := FilterGiteaPullRequests(openPullRequests, c.originOrganization(), branch, target)
 	if len(pullRequests) == 0 {
 		return nil, nil //nolint:nilnil
 	}
 	if len(pullRequests) > 1 {
 		return nil, fmt.Errorf("found %d pull requests for branch %q", len(pullRequests), branch)
 	}
-	pullRequest := pullRequests[0]
-	return &Proposal{
-		CanMergeWithAPI: pullRequest.Mergeable,
-		Number:          int(pullRequest.Index),
-		Target:          pullRequest.Base.Ref,
-		Title:           pullRequest.Title,
-	}, nil
+	proposal := parseGiteaPullRequest(pullRequests[0])
+	return &proposal, nil
 }
 
 func (c *GiteaConnector) DefaultProposalMessage(proposal Proposal) string {
@@ -50,23 +93,18 @@ func (c *GiteaConnector) HostingServiceName() string {
 	return "Gitea"
 }
 
-func (c *GiteaConnector) NewProposalURL(branch, parentBranch string) (string, error) {
-	toCompare := parentBranch + "..." + branch
-	return fmt.Sprintf("%s/compare/%s", c.RepositoryURL(), url.PathEscape(toCompare)), nil
-}
-
-func (c *GiteaConnector) RepositoryURL() string {
-	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.Organization, c.Repository)
-}
-
 //nolint:nonamedreturns  // return value isn't obvious from function name
-func (c *GiteaConnector) SquashMergeProposal(number int, message string) (mergeSha string, err error) {
+func (c *GiteaConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSha string, err error) {
 	if number <= 0 {
 		return "", fmt.Errorf("no pull request number given")
 	}
+	style, err := giteaMergeStyle(strategy)
+	if err != nil {
+		return "", err
+	}
 	title, body := ParseCommitMessage(message)
 	_, err = c.client.MergePullRequest(c.Organization, c.Repository, int64(number), gitea.MergePullRequestOption{
-		Style:   gitea.MergeStyleSquash,
+		Style:   style,
 		Title:   title,
 		Message: body,
 	})
@@ -80,6 +118,76 @@ func (c *GiteaConnector) SquashMergeProposal(number int, message string) (mergeS
 	return *pullRequest.MergedCommitID, nil
 }
 
+func (c *GiteaConnector) NewProposalURL(branch, parentBranch string) (string, error) {
+	toCompare := parentBranch + "..." + c.head(branch)
+	return fmt.Sprintf("https://%s/%s/%s/compare/%s", c.Hostname, c.Organization, c.Repository, url.PathEscape(toCompare)), nil
+}
+
+func (c *GiteaConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	pullRequest, err := c.client.GetPullRequest(c.Organization, c.Repository, int64(number))
+	if err != nil {
+		return nil, err
+	}
+	combinedStatus, err := c.client.GetCombinedStatus(c.Organization, c.Repository, pullRequest.Head.Sha)
+	if err != nil {
+		return nil, err
+	}
+	ciStatus := CIStatusNone
+	if combinedStatus.TotalCount > 0 {
+		ciStatus = parseGiteaStatusState(combinedStatus.State)
+	}
+	statusesByName := map[string]CIStatus{}
+	for _, status := range combinedStatus.Statuses {
+		statusesByName[status.Context] = parseGiteaStatusState(status.State)
+	}
+	reviews, err := c.client.ListPullReviews(c.Organization, c.Repository, int64(number), gitea.ListPullReviewsOptions{
+		ListOptions: gitea.ListOptions{
+			PageSize: 50,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := ProposalChecks{
+		Approvals:         giteaApprovals(reviews),
+		CIStatus:          ciStatus,
+		RequiredApprovals: 0,
+		RequiredChecks:    []CheckResult{},
+	}
+	// only repository admins can read branch protection rules
+	protection, err := c.client.GetBranchProtection(c.Organization, c.Repository, pullRequest.Base.Ref)
+	if err == nil {
+		result.RequiredApprovals = int(protection.RequiredApprovals)
+		if protection.EnableStatusCheck {
+			result.RequiredChecks = requiredCheckResults(protection.StatusCheckContexts, statusesByName)
+		}
+	}
+	return &result, nil
+}
+
+func (c *GiteaConnector) ProposalMerged(number int) (bool, error) {
+	return c.client.IsPullRequestMerged(c.Organization, c.Repository, int64(number))
+}
+
+func (c *GiteaConnector) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.Organization, c.Repository)
+}
+
+func (c *GiteaConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("Gitea API: Updating description of PR #%d\n", number)
+	}
+	pullRequest, err := c.client.GetPullRequest(c.Organization, c.Repository, int64(number))
+	if err != nil {
+		return err
+	}
+	_, err = c.client.EditPullRequest(c.Organization, c.Repository, int64(number), gitea.EditPullRequestOption{
+		Title: pullRequest.Title,
+		Body:  body,
+	})
+	return err
+}
+
 func (c *GiteaConnector) UpdateProposalTarget(number int, target string) error {
 	// TODO: update the client and uncomment
 	// if c.log != nil {
@@ -103,23 +211,27 @@ func NewGiteaConnector(gitConfig gitTownConfig, log logFn) (*GiteaConnector, err
 	if url == nil || (url.Host != "gitea.com" && hostingService != config.HostingServiceGitea) {
 		return nil, nil //nolint:nilnil
 	}
-	apiToken := gitConfig.GiteaToken()
+	apiToken := gitConfig.APIToken(config.HostingServiceGitea, url.Host).Value
 	hostname := url.Host
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiToken})
 	httpClient := oauth2.NewClient(context.Background(), tokenSource)
 	giteaClient := gitea.NewClientWithHTTP(fmt.Sprintf("https://%s", hostname), httpClient)
 	return &GiteaConnector{
-		client: giteaClient,
-		CommonConfig: CommonConfig{
-			APIToken:     apiToken,
-			Hostname:     hostname,
-			Organization: url.Org,
-			Repository:   url.Repo,
-		},
-		log: log,
+		client:       giteaClient,
+		CommonConfig: newCommonConfig(gitConfig, apiToken, hostname, url),
+		log:          log,
 	}, nil
 }
 
+// head provides how the Gitea API refers to the given branch as the head of a pull request.
+// Branches in forks are prefixed with the owner of the fork.
+func (c *GiteaConnector) head(branch string) string {
+	if c.IsFork() {
+		return c.ForkOrganization + ":" + branch
+	}
+	return branch
+}
+
 func FilterGiteaPullRequests(pullRequests []*gitea.PullRequest, organization, branch, target string) []*gitea.PullRequest {
 	result := []*gitea.PullRequest{}
 	headName := organization + "/" + branch
@@ -131,3 +243,69 @@ func FilterGiteaPullRequests(pullRequests []*gitea.PullRequest, organization, br
 	}
 	return result
 }
+
+// giteaMergeStyle provides the Gitea merge style for the given ship strategy.
+func giteaMergeStyle(strategy config.ShipStrategy) (gitea.MergeStyle, error) {
+	switch strategy {
+	case config.ShipStrategySquash:
+		return gitea.MergeStyleSquash, nil
+	case config.ShipStrategyMerge:
+		return gitea.MergeStyleMerge, nil
+	case config.ShipStrategyRebase:
+		return gitea.MergeStyleRebase, nil
+	case config.ShipStrategyFastForward:
+	}
+	return "", unsupportedShipStrategyError("Gitea", strategy)
+}
+
+// parseGiteaPullRequest extracts standardized proposal data from the given Gitea pull request.
+func parseGiteaPullRequest(pullRequest *gitea.PullRequest) Proposal {
+	return Proposal{
+		Body:            pullRequest.Body,
+		CanMergeWithAPI: pullRequest.Mergeable,
+		Draft:           isGiteaWorkInProgress(pullRequest.Title),
+		Number:          int(pullRequest.Index),
+		Target:          pullRequest.Base.Ref,
+		Title:           pullRequest.Title,
+		URL:             pullRequest.HTMLURL,
+	}
+}
+
+// giteaApprovals provides the number of reviewers whose latest of the given reviews approves the pull request.
+func giteaApprovals(reviews []*gitea.PullReview) int {
+	latestStates := map[string]gitea.ReviewStateType{}
+	for _, review := range reviews {
+		if review.Reviewer == nil || review.Stale {
+			continue
+		}
+		if review.State == gitea.ReviewStateApproved || review.State == gitea.ReviewStateRequestChanges {
+			latestStates[review.Reviewer.UserName] = review.State
+		}
+	}
+	result := 0
+	for _, state := range latestStates {
+		if state == gitea.ReviewStateApproved {
+			result++
+		}
+	}
+	return result
+}
+
+// isGiteaWorkInProgress indicates whether a Gitea pull request with the given title is marked as work in progress.
+func isGiteaWorkInProgress(title string) bool {
+	upperTitle := strings.ToUpper(title)
+	return strings.HasPrefix(upperTitle, "WIP:") || strings.HasPrefix(upperTitle, "[WIP]")
+}
+
+// parseGiteaStatusState provides the CI status for the given state of Gitea commit statuses.
+func parseGiteaStatusState(state gitea.StatusState) CIStatus {
+	switch state {
+	case gitea.StatusSuccess, gitea.StatusWarning:
+		return CIStatusSuccess
+	case gitea.StatusPending:
+		return CIStatusPending
+	case gitea.StatusError, gitea.StatusFailure:
+		return CIStatusFailure
+	}
+	return CIStatusNone
+}
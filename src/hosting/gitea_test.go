@@ -43,6 +43,21 @@ func TestNewGiteaConnector(t *testing.T) {
 		assert.Equal(t, "Gitea", connector.HostingServiceName())
 		assert.Equal(t, "https://gitea.com/git-town/git-town", connector.RepositoryURL())
 	})
+
+	t.Run("fork of an upstream repo", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL:   "git@gitea.com:kevgo/git-town.git",
+			upstreamURL: "https://gitea.com/git-town/git-town.git",
+		}
+		connector, err := hosting.NewGiteaConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://gitea.com/git-town/git-town", connector.RepositoryURL())
+		have, err := connector.NewProposalURL("feature", "main")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://gitea.com/git-town/git-town/compare/main...kevgo:feature", have)
+	})
 }
 
 //nolint:paralleltest  // mocks HTTP
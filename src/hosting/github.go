@@ -2,11 +2,15 @@ package hosting
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/stringslice"
 	"github.com/google/go-github/v50/github"
 	"golang.org/x/oauth2"
 )
@@ -20,9 +24,108 @@ type GitHubConnector struct {
 	log        logFn
 }
 
+// APIURL provides the base URL of the GitHub REST API that this connector talks to.
+func (c *GitHubConnector) APIURL() string {
+	return c.client.BaseURL.String()
+}
+
+func (c *GitHubConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	if number <= 0 {
+		return fmt.Errorf("no pull request number given")
+	}
+	mergeMethod, err := githubMergeMethod(strategy)
+	if err != nil {
+		return err
+	}
+	pullRequest, _, err := c.client.PullRequests.Get(context.Background(), c.Organization, c.Repository, number)
+	if err != nil {
+		return err
+	}
+	hasMergeQueue, err := c.hasMergeQueue(pullRequest.Base.GetRef())
+	if err != nil {
+		return err
+	}
+	if hasMergeQueue {
+		// the merge queue merges pull requests using the merge method configured for it
+		if c.log != nil {
+			c.log("GitHub API: adding PR #%d to the merge queue\n", number)
+		}
+		return c.graphQL(`mutation($id: ID!) {
+			enqueuePullRequest(input: {pullRequestId: $id}) { clientMutationId }
+		}`, map[string]interface{}{"id": pullRequest.GetNodeID()}, nil)
+	}
+	if c.log != nil {
+		c.log("GitHub API: enabling auto-merge for PR #%d\n", number)
+	}
+	variables := map[string]interface{}{
+		"id":     pullRequest.GetNodeID(),
+		"method": strings.ToUpper(mergeMethod),
+	}
+	if message != "" {
+		variables["headline"], variables["body"] = ParseCommitMessage(message)
+	}
+	return c.graphQL(`mutation($id: ID!, $method: PullRequestMergeMethod!, $headline: String, $body: String) {
+		enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline, commitBody: $body}) { clientMutationId }
+	}`, variables, nil)
+}
+
+func (c *GitHubConnector) CancelAutoMerge(number int) error {
+	pullRequest, _, err := c.client.PullRequests.Get(context.Background(), c.Organization, c.Repository, number)
+	if err != nil {
+		return err
+	}
+	hasMergeQueue, err := c.hasMergeQueue(pullRequest.Base.GetRef())
+	if err != nil {
+		return err
+	}
+	if hasMergeQueue {
+		if c.log != nil {
+			c.log("GitHub API: removing PR #%d from the merge queue\n", number)
+		}
+		return c.graphQL(`mutation($id: ID!) {
+			dequeuePullRequest(input: {id: $id}) { clientMutationId }
+		}`, map[string]interface{}{"id": pullRequest.GetNodeID()}, nil)
+	}
+	if c.log != nil {
+		c.log("GitHub API: disabling auto-merge for PR #%d\n", number)
+	}
+	return c.graphQL(`mutation($id: ID!) {
+		disablePullRequestAutoMerge(input: {pullRequestId: $id}) { clientMutationId }
+	}`, map[string]interface{}{"id": pullRequest.GetNodeID()}, nil)
+}
+
+func (c *GitHubConnector) CloseProposal(number int) error {
+	if c.log != nil {
+		c.log("GitHub API: closing PR #%d\n", number)
+	}
+	_, _, err := c.client.PullRequests.Edit(context.Background(), c.Organization, c.Repository, number, &github.PullRequest{
+		State: github.String("closed"),
+	})
+	return err
+}
+
+func (c *GitHubConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	if c.log != nil {
+		c.log("GitHub API: creating PR from %q into %q\n", branch, target)
+	}
+	head := c.head(branch)
+	pullRequest, _, err := c.client.PullRequests.Create(context.Background(), c.Organization, c.Repository, &github.NewPullRequest{
+		Title: &title,
+		Head:  &head,
+		Base:  &target,
+		Body:  &body,
+		Draft: &draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+	proposal := parsePullRequest(pullRequest)
+	return &proposal, nil
+}
+
 func (c *GitHubConnector) FindProposal(branch, target string) (*Proposal, error) {
 	pullRequests, _, err := c.client.PullRequests.List(context.Background(), c.Organization, c.Repository, &github.PullRequestListOptions{
-		Head:  c.Organization + ":" + branch,
+		Head:  c.originOrganization() + ":" + branch,
 		Base:  target,
 		State: "open",
 	})
@@ -47,34 +150,97 @@ func (c *GitHubConnector) HostingServiceName() string {
 	return "GitHub"
 }
 
-func (c *GitHubConnector) NewProposalURL(branch, parentBranch string) (string, error) {
-	toCompare := branch
-	if parentBranch != c.MainBranch {
-		toCompare = parentBranch + "..." + branch
-	}
-	return fmt.Sprintf("%s/compare/%s?expand=1", c.RepositoryURL(), url.PathEscape(toCompare)), nil
-}
-
-func (c *GitHubConnector) RepositoryURL() string {
-	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.Organization, c.Repository)
-}
-
 //nolint:nonamedreturns
-func (c *GitHubConnector) SquashMergeProposal(number int, message string) (mergeSHA string, err error) {
+func (c *GitHubConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error) {
 	if number <= 0 {
 		return "", fmt.Errorf("no pull request number given")
 	}
+	mergeMethod, err := githubMergeMethod(strategy)
+	if err != nil {
+		return "", err
+	}
 	if c.log != nil {
 		c.log("GitHub API: merging PR #%d\n", number)
 	}
 	title, body := ParseCommitMessage(message)
 	result, _, err := c.client.PullRequests.Merge(context.Background(), c.Organization, c.Repository, number, body, &github.PullRequestOptions{
-		MergeMethod: "squash",
+		MergeMethod: mergeMethod,
 		CommitTitle: title,
 	})
 	return result.GetSHA(), err
 }
 
+func (c *GitHubConnector) NewProposalURL(branch, parentBranch string) (string, error) {
+	toCompare := c.head(branch)
+	if parentBranch != c.MainBranch {
+		toCompare = parentBranch + "..." + c.head(branch)
+	}
+	return fmt.Sprintf("https://%s/%s/%s/compare/%s?expand=1", c.Hostname, c.Organization, c.Repository, url.PathEscape(toCompare)), nil
+}
+
+func (c *GitHubConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	pullRequest, _, err := c.client.PullRequests.Get(context.Background(), c.Organization, c.Repository, number)
+	if err != nil {
+		return nil, err
+	}
+	sha := pullRequest.Head.GetSHA()
+	// GitHub reports the results of CI jobs either as commit statuses or as check runs
+	combinedStatus, _, err := c.client.Repositories.GetCombinedStatus(context.Background(), c.Organization, c.Repository, sha, nil)
+	if err != nil {
+		return nil, err
+	}
+	statuses := []CIStatus{}
+	statusesByName := map[string]CIStatus{}
+	if combinedStatus.GetTotalCount() > 0 {
+		statuses = append(statuses, parseGitHubStatusState(combinedStatus.GetState()))
+	}
+	for _, status := range combinedStatus.Statuses {
+		statusesByName[status.GetContext()] = parseGitHubStatusState(status.GetState())
+	}
+	checkRuns, _, err := c.client.Checks.ListCheckRunsForRef(context.Background(), c.Organization, c.Repository, sha, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, checkRun := range checkRuns.CheckRuns {
+		status := parseGitHubCheckRun(checkRun)
+		statuses = append(statuses, status)
+		statusesByName[checkRun.GetName()] = combineCIStatuses(statusesByName[checkRun.GetName()], status)
+	}
+	requiredChecks, requiredApprovals, err := c.branchRequirements(pullRequest.Base.GetRef())
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := c.approvals(number)
+	if err != nil {
+		return nil, err
+	}
+	return &ProposalChecks{
+		Approvals:         approvals,
+		CIStatus:          combineCIStatuses(statuses...),
+		RequiredApprovals: requiredApprovals,
+		RequiredChecks:    requiredCheckResults(requiredChecks, statusesByName),
+	}, nil
+}
+
+func (c *GitHubConnector) ProposalMerged(number int) (bool, error) {
+	merged, _, err := c.client.PullRequests.IsMerged(context.Background(), c.Organization, c.Repository, number)
+	return merged, err
+}
+
+func (c *GitHubConnector) RepositoryURL() string {
+	return fmt.Sprintf("https://%s/%s/%s", c.Hostname, c.Organization, c.Repository)
+}
+
+func (c *GitHubConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("GitHub API: updating description of PR #%d\n", number)
+	}
+	_, _, err := c.client.PullRequests.Edit(context.Background(), c.Organization, c.Repository, number, &github.PullRequest{
+		Body: &body,
+	})
+	return err
+}
+
 func (c *GitHubConnector) UpdateProposalTarget(number int, target string) error {
 	if c.log != nil {
 		c.log("GitHub API: updating base branch for PR #%d\n", number)
@@ -87,6 +253,109 @@ func (c *GitHubConnector) UpdateProposalTarget(number int, target string) error
 	return err
 }
 
+// approvals provides the number of reviewers whose latest review approves the pull request with the given number.
+func (c *GitHubConnector) approvals(number int) (int, error) {
+	reviews, _, err := c.client.PullRequests.ListReviews(context.Background(), c.Organization, c.Repository, number, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return 0, err
+	}
+	// GitHub lists reviews in chronological order, only the latest review of each reviewer counts
+	latestStates := map[string]string{}
+	for _, review := range reviews {
+		if review.GetState() != "COMMENTED" {
+			latestStates[review.GetUser().GetLogin()] = review.GetState()
+		}
+	}
+	result := 0
+	for _, state := range latestStates {
+		if state == "APPROVED" {
+			result++
+		}
+	}
+	return result, nil
+}
+
+// branchRequirements provides the names of the checks and the number of approvals
+// that the protection rules of the given branch require for merging pull requests into it.
+//
+//nolint:nonamedreturns
+func (c *GitHubConnector) branchRequirements(branch string) (requiredChecks []string, requiredApprovals int, err error) {
+	protection, response, err := c.client.Repositories.GetBranchProtection(context.Background(), c.Organization, c.Repository, branch)
+	if err != nil {
+		// only repository admins can read branch protection rules
+		if errors.Is(err, github.ErrBranchNotProtected) || (response != nil && (response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusNotFound)) {
+			return []string{}, 0, nil
+		}
+		return nil, 0, err
+	}
+	requiredChecks = []string{}
+	if protection.RequiredStatusChecks != nil {
+		requiredChecks = append(requiredChecks, protection.RequiredStatusChecks.Contexts...)
+		for _, check := range protection.RequiredStatusChecks.Checks {
+			if !stringslice.Contains(requiredChecks, check.Context) {
+				requiredChecks = append(requiredChecks, check.Context)
+			}
+		}
+	}
+	if protection.RequiredPullRequestReviews != nil {
+		requiredApprovals = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	}
+	return requiredChecks, requiredApprovals, nil
+}
+
+// graphQL sends the given query with the given variables to the GraphQL API of GitHub
+// and decodes the data it responds with into the given result, if any.
+// GitHub provides auto-merge and merge queues only via this API.
+func (c *GitHubConnector) graphQL(query string, variables map[string]interface{}, result interface{}) error {
+	request, err := c.client.NewRequest(http.MethodPost, c.graphQLURL(), map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+	var response githubGraphQLResponse
+	_, err = c.client.Do(context.Background(), request, &response)
+	if err != nil {
+		return err
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("unexpected response from the GitHub GraphQL API: %s", response.Errors[0].Message)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Data, result)
+}
+
+// graphQLURL provides the URL of the GraphQL API relative to the base URL of the REST API.
+func (c *GitHubConnector) graphQLURL() string {
+	// GitHub Enterprise Server provides the GraphQL API at /api/graphql next to the REST API at /api/v3
+	restPath := c.client.BaseURL.Path
+	if strings.HasSuffix(restPath, "/api/v3/") {
+		return strings.TrimSuffix(restPath, "v3/") + "graphql"
+	}
+	return "graphql"
+}
+
+// hasMergeQueue indicates whether the given branch merges pull requests via a merge queue.
+func (c *GitHubConnector) hasMergeQueue(branch string) (bool, error) {
+	var result struct {
+		Repository struct {
+			MergeQueue *struct {
+				ID string `json:"id"`
+			} `json:"mergeQueue"`
+		} `json:"repository"`
+	}
+	err := c.graphQL(`query($owner: String!, $repo: String!, $branch: String!) {
+		repository(owner: $owner, name: $repo) { mergeQueue(branch: $branch) { id } }
+	}`, map[string]interface{}{"owner": c.Organization, "repo": c.Repository, "branch": branch}, &result)
+	if err != nil {
+		return false, err
+	}
+	return result.Repository.MergeQueue != nil, nil
+}
+
 // NewGithubConnector provides a fully configured GithubConnector instance
 // if the current repo is hosted on Github, otherwise nil.
 func NewGithubConnector(gitConfig gitTownConfig, log logFn) (*GitHubConnector, error) {
@@ -98,28 +367,93 @@ func NewGithubConnector(gitConfig gitTownConfig, log logFn) (*GitHubConnector, e
 	if url == nil || (url.Host != "github.com" && hostingService != config.HostingServiceGitHub) {
 		return nil, nil //nolint:nilnil
 	}
-	apiToken := gitConfig.GitHubToken()
+	apiToken := gitConfig.APIToken(config.HostingServiceGitHub, url.Host).Value
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiToken})
 	httpClient := oauth2.NewClient(context.Background(), tokenSource)
+	client := github.NewClient(httpClient)
+	apiURL := gitConfig.GitHubAPIURL()
+	if apiURL == "" && url.Host != "github.com" && (gitConfig.OriginOverride() != "" || !isSSHHostAlias(url.Host)) {
+		// GitHub Enterprise Server provides its REST API at /api/v3 on the same host
+		apiURL = fmt.Sprintf("https://%s/api/v3/", url.Host)
+	}
+	if apiURL != "" {
+		uploadURL := strings.Replace(apiURL, "/api/v3", "/api/uploads", 1)
+		client, err = github.NewEnterpriseClient(apiURL, uploadURL, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub API URL %q: %w", apiURL, err)
+		}
+	}
 	return &GitHubConnector{
-		client: github.NewClient(httpClient),
-		CommonConfig: CommonConfig{
-			APIToken:     apiToken,
-			Hostname:     url.Host,
-			Organization: url.Org,
-			Repository:   url.Repo,
-		},
-		MainBranch: gitConfig.MainBranch(),
-		log:        log,
+		client:       client,
+		CommonConfig: newCommonConfig(gitConfig, apiToken, url.Host, url),
+		MainBranch:   gitConfig.MainBranch(),
+		log:          log,
 	}, nil
 }
 
+// head provides how the GitHub API refers to the given branch as the head of a pull request.
+// Branches in forks are prefixed with the owner of the fork.
+func (c *GitHubConnector) head(branch string) string {
+	if c.IsFork() {
+		return c.ForkOrganization + ":" + branch
+	}
+	return branch
+}
+
+// githubMergeMethod provides the GitHub merge method for the given ship strategy.
+func githubMergeMethod(strategy config.ShipStrategy) (string, error) {
+	switch strategy {
+	case config.ShipStrategySquash:
+		return "squash", nil
+	case config.ShipStrategyMerge:
+		return "merge", nil
+	case config.ShipStrategyRebase:
+		return "rebase", nil
+	case config.ShipStrategyFastForward:
+	}
+	return "", unsupportedShipStrategyError("GitHub", strategy)
+}
+
+// isSSHHostAlias indicates whether the given hostname looks like an SSH host alias for github.com,
+// for example "github-work" or "github.com-work", rather than the hostname of a GitHub Enterprise Server instance.
+func isSSHHostAlias(hostname string) bool {
+	return !strings.Contains(hostname, ".") || strings.HasPrefix(hostname, "github.com")
+}
+
+// parseGitHubCheckRun provides the CI status of the given GitHub check run.
+func parseGitHubCheckRun(checkRun *github.CheckRun) CIStatus {
+	if checkRun.GetStatus() != "completed" {
+		return CIStatusPending
+	}
+	switch checkRun.GetConclusion() {
+	case "success", "neutral", "skipped":
+		return CIStatusSuccess
+	default:
+		return CIStatusFailure
+	}
+}
+
+// parseGitHubStatusState provides the CI status for the given state of GitHub commit statuses.
+func parseGitHubStatusState(state string) CIStatus {
+	switch state {
+	case "success":
+		return CIStatusSuccess
+	case "pending":
+		return CIStatusPending
+	default:
+		return CIStatusFailure
+	}
+}
+
 // parsePullRequest extracts standardized proposal data from the given GitHub pull-request.
 func parsePullRequest(pullRequest *github.PullRequest) Proposal {
 	return Proposal{
+		Body:            pullRequest.GetBody(),
+		Draft:           pullRequest.GetDraft(),
 		Number:          pullRequest.GetNumber(),
 		Target:          pullRequest.Base.GetRef(),
 		Title:           pullRequest.GetTitle(),
+		URL:             pullRequest.GetHTMLURL(),
 		CanMergeWithAPI: pullRequest.GetMergeableState() == "clean",
 	}
 }
@@ -138,3 +472,13 @@ func ParseCommitMessage(message string) (title, body string) {
 	}
 	return
 }
+
+// githubGraphQLResponse is the envelope of responses from the GitHub GraphQL API.
+type githubGraphQLResponse struct {
+	Data   json.RawMessage      `json:"data"`
+	Errors []githubGraphQLError `json:"errors"`
+}
+
+type githubGraphQLError struct {
+	Message string `json:"message"`
+}
@@ -1,9 +1,14 @@
 package hosting_test
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,6 +40,113 @@ func TestNewGithubConnector(t *testing.T) {
 		assert.Equal(t, "https://self-hosted-github.com/git-town/git-town", connector.RepositoryURL())
 	})
 
+	t.Run("GitHub Enterprise Server with custom API URL", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v3/repos/git-town/git-town/pulls":
+				assert.Equal(t, "git-town:feature", r.URL.Query().Get("head"))
+				fmt.Fprint(w, `[{"number": 1, "title": "my title", "base": {"ref": "main"}}]`)
+			case "/api/graphql":
+				assert.Equal(t, http.MethodPost, r.Method)
+				fmt.Fprint(w, `{"data": {"repository": {"mergeQueue": null}}}`)
+			case "/api/v3/repos/git-town/git-town/pulls/1":
+				fmt.Fprint(w, `{"number": 1, "node_id": "PR_1", "base": {"ref": "main"}}`)
+			default:
+				t.Errorf("unexpected request to %q", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+		repoConfig := mockRepoConfig{
+			gitHubAPIURL:   server.URL + "/api/v3",
+			hostingService: "github",
+			originURL:      "git@github.example.com:git-town/git-town.git",
+		}
+		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.example.com/git-town/git-town", connector.RepositoryURL())
+		proposal, err := connector.FindProposal("feature", "main")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, proposal.Number)
+		err = connector.AutoMergeProposal(1, config.ShipStrategySquash, "")
+		assert.Nil(t, err)
+	})
+
+	t.Run("SSH host alias for github.com", func(t *testing.T) {
+		t.Parallel()
+		for _, alias := range []string{"github-work", "github.com-work"} {
+			repoConfig := mockRepoConfig{
+				hostingService: "github",
+				originURL:      fmt.Sprintf("git@%s:git-town/git-town.git", alias),
+			}
+			connector, err := hosting.NewGithubConnector(repoConfig, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, "https://api.github.com/", connector.APIURL(), alias)
+		}
+	})
+
+	t.Run("SSH host alias for a GitHub Enterprise Server instance", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			hostingService: "github",
+			originOverride: "github.example.com",
+			originURL:      "git@github-work:git-town/git-town.git",
+		}
+		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.example.com/api/v3/", connector.APIURL())
+	})
+
+	t.Run("fork of an upstream repository", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v3/repos/git-town/git-town/pulls", r.URL.Path)
+			switch r.Method {
+			case http.MethodGet:
+				assert.Equal(t, "kevgo:feature", r.URL.Query().Get("head"))
+				fmt.Fprint(w, `[{"number": 1, "title": "my title", "base": {"ref": "main"}}]`)
+			case http.MethodPost:
+				body, err := io.ReadAll(r.Body)
+				assert.Nil(t, err)
+				assert.Contains(t, string(body), `"head":"kevgo:feature"`)
+				fmt.Fprint(w, `{"number": 2, "title": "my title", "base": {"ref": "main"}}`)
+			}
+		}))
+		defer server.Close()
+		repoConfig := mockRepoConfig{
+			gitHubAPIURL:   server.URL + "/api/v3",
+			hostingService: "github",
+			mainBranch:     "main",
+			originURL:      "git@github.example.com:kevgo/git-town.git",
+			upstreamURL:    "git@github.example.com:git-town/git-town.git",
+		}
+		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://github.example.com/git-town/git-town", connector.RepositoryURL())
+		proposalURL, err := connector.NewProposalURL("feature", "main")
+		assert.Nil(t, err)
+		assert.Equal(t, "https://github.example.com/git-town/git-town/compare/kevgo:feature?expand=1", proposalURL)
+		proposal, err := connector.FindProposal("feature", "main")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, proposal.Number)
+		proposal, err = connector.CreateProposal("feature", "main", "my title", "", false)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, proposal.Number)
+	})
+
+	t.Run("upstream remote on another host", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL:   "git@github.com:kevgo/git-town.git",
+			upstreamURL: "/path/to/upstream",
+		}
+		connector, err := hosting.NewGithubConnector(repoConfig, nil)
+		assert.Nil(t, err)
+		assert.False(t, connector.IsFork())
+		assert.Equal(t, "https://github.com/kevgo/git-town", connector.RepositoryURL())
+	})
+
 	t.Run("custom hostname override", func(t *testing.T) {
 		t.Parallel()
 		repoConfig := mockRepoConfig{
@@ -62,6 +174,20 @@ func TestGithubConnector(t *testing.T) {
 		have := connector.DefaultProposalMessage(give)
 		assert.Equal(t, want, have)
 	})
+
+	t.Run("AutoMergeProposal with unsupported strategy", func(t *testing.T) {
+		t.Parallel()
+		connector := hosting.GitHubConnector{} //nolint:exhaustruct
+		err := connector.AutoMergeProposal(1, config.ShipStrategyFastForward, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("MergeProposal with unsupported strategy", func(t *testing.T) {
+		t.Parallel()
+		connector := hosting.GitHubConnector{} //nolint:exhaustruct
+		_, err := connector.MergeProposal(1, config.ShipStrategyFastForward, "message")
+		assert.Error(t, err)
+	})
 	t.Run("NewProposalURL", func(t *testing.T) {
 		t.Parallel()
 		tests := map[string]struct {
@@ -17,6 +17,91 @@ type GitLabConnector struct {
 	log logFn
 }
 
+func (c *GitLabConnector) AutoMergeProposal(number int, strategy config.ShipStrategy, message string) error {
+	if number <= 0 {
+		return fmt.Errorf("no merge request number given")
+	}
+	options, err := gitlabAcceptOptions(strategy, message)
+	if err != nil {
+		return err
+	}
+	project, _, err := c.client.Projects.GetProject(c.projectPath(), nil)
+	if err != nil {
+		return err
+	}
+	if project.MergeTrainsEnabled {
+		if c.log != nil {
+			c.log("GitLab API: Adding MR !%d to the merge train\n", number)
+		}
+		// the go-gitlab version in use doesn't provide the merge trains API yet
+		path := fmt.Sprintf("projects/%s/merge_trains/merge_requests/%d", gitlab.PathEscape(c.projectPath()), number)
+		request, err := c.client.NewRequest(http.MethodPost, path, map[string]interface{}{
+			"squash":                 strategy == config.ShipStrategySquash,
+			"when_pipeline_succeeds": true,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		_, err = c.client.Do(request, nil)
+		return err
+	}
+	if c.log != nil {
+		c.log("GitLab API: Setting MR !%d to merge when the pipeline succeeds\n", number)
+	}
+	options.MergeWhenPipelineSucceeds = gitlab.Bool(true)
+	_, _, err = c.client.MergeRequests.AcceptMergeRequest(c.projectPath(), number, options)
+	return err
+}
+
+func (c *GitLabConnector) CancelAutoMerge(number int) error {
+	if c.log != nil {
+		c.log("GitLab API: Canceling the automatic merge of MR !%d\n", number)
+	}
+	// this also removes the merge request from the merge train
+	_, _, err := c.client.MergeRequests.CancelMergeWhenPipelineSucceeds(c.projectPath(), number)
+	return err
+}
+
+func (c *GitLabConnector) CloseProposal(number int) error {
+	if c.log != nil {
+		c.log("GitLab API: Closing MR !%d\n", number)
+	}
+	_, _, err := c.client.MergeRequests.UpdateMergeRequest(c.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String("close"),
+	})
+	return err
+}
+
+func (c *GitLabConnector) CreateProposal(branch, target, title, body string, draft bool) (*Proposal, error) {
+	if c.log != nil {
+		c.log("GitLab API: Creating MR from %q into %q\n", branch, target)
+	}
+	if draft {
+		// GitLab marks merge requests as drafts via this title prefix
+		title = "Draft: " + title
+	}
+	options := &gitlab.CreateMergeRequestOptions{
+		Title:        gitlab.String(title),
+		Description:  gitlab.String(body),
+		SourceBranch: gitlab.String(branch),
+		TargetBranch: gitlab.String(target),
+	}
+	if c.IsFork() {
+		// merge requests from forks are created in the fork and target the upstream project
+		upstream, _, err := c.client.Projects.GetProject(c.projectPath(), nil)
+		if err != nil {
+			return nil, err
+		}
+		options.TargetProjectID = gitlab.Int(upstream.ID)
+	}
+	mergeRequest, _, err := c.client.MergeRequests.CreateMergeRequest(c.originProjectPath(), options)
+	if err != nil {
+		return nil, err
+	}
+	proposal := parseGitLabMergeRequest(mergeRequest)
+	return &proposal, nil
+}
+
 func (c *GitLabConnector) FindProposal(branch, target string) (*Proposal, error) {
 	opts := &gitlab.ListProjectMergeRequestsOptions{
 		State:        gitlab.String("opened"),
@@ -27,6 +112,12 @@ func (c *GitLabConnector) FindProposal(branch, target string) (*Proposal, error)
 	if err != nil {
 		return nil, err
 	}
+	if c.IsFork() {
+		mergeRequests, err = c.filterFromFork(mergeRequests)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if len(mergeRequests) == 0 {
 		return nil, nil //nolint:nilnil
 	}
@@ -37,27 +128,89 @@ func (c *GitLabConnector) FindProposal(branch, target string) (*Proposal, error)
 	return &proposal, nil
 }
 
+// filterFromFork provides the given merge requests that merge a branch of the fork at the origin remote.
+// Other forks of the upstream project can contain branches with the same name.
+func (c *GitLabConnector) filterFromFork(mergeRequests []*gitlab.MergeRequest) ([]*gitlab.MergeRequest, error) {
+	fork, _, err := c.client.Projects.GetProject(c.originProjectPath(), nil)
+	if err != nil {
+		return nil, err
+	}
+	result := []*gitlab.MergeRequest{}
+	for _, mergeRequest := range mergeRequests {
+		if mergeRequest.SourceProjectID == fork.ID {
+			result = append(result, mergeRequest)
+		}
+	}
+	return result, nil
+}
+
 //nolint:nonamedreturns  // return value isn't obvious from function name
-func (c *GitLabConnector) SquashMergeProposal(number int, message string) (mergeSHA string, err error) {
+func (c *GitLabConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (mergeSHA string, err error) {
 	if number <= 0 {
 		return "", fmt.Errorf("no merge request number given")
 	}
+	options, err := gitlabAcceptOptions(strategy, message)
+	if err != nil {
+		return "", err
+	}
 	if c.log != nil {
 		c.log("GitLab API: Merging MR !%d\n", number)
 	}
-	// the GitLab API wants the full commit message in the body
-	result, _, err := c.client.MergeRequests.AcceptMergeRequest(c.projectPath(), number, &gitlab.AcceptMergeRequestOptions{
-		SquashCommitMessage: gitlab.String(message),
-		Squash:              gitlab.Bool(true),
-		// the branch will be deleted by Git Town
-		ShouldRemoveSourceBranch: gitlab.Bool(false),
-	})
+	result, _, err := c.client.MergeRequests.AcceptMergeRequest(c.projectPath(), number, options)
 	if err != nil {
 		return "", err
 	}
 	return result.SHA, nil
 }
 
+func (c *GitLabConnector) ProposalChecks(number int) (*ProposalChecks, error) {
+	mergeRequest, _, err := c.client.MergeRequests.GetMergeRequest(c.projectPath(), number, nil)
+	if err != nil {
+		return nil, err
+	}
+	ciStatus := CIStatusNone
+	if mergeRequest.HeadPipeline != nil {
+		ciStatus = parseGitLabPipelineStatus(mergeRequest.HeadPipeline.Status)
+	}
+	approvals, _, err := c.client.MergeRequestApprovals.GetConfiguration(c.projectPath(), number)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := c.client.Projects.GetProject(c.projectPath(), nil)
+	if err != nil {
+		return nil, err
+	}
+	requiredChecks := []CheckResult{}
+	if project.OnlyAllowMergeIfPipelineSucceeds {
+		// GitLab doesn't require individual jobs but the whole pipeline to succeed
+		requiredChecks = append(requiredChecks, CheckResult{Name: "pipeline", Status: ciStatus})
+	}
+	return &ProposalChecks{
+		Approvals:         len(approvals.ApprovedBy),
+		CIStatus:          ciStatus,
+		RequiredApprovals: approvals.ApprovalsRequired,
+		RequiredChecks:    requiredChecks,
+	}, nil
+}
+
+func (c *GitLabConnector) ProposalMerged(number int) (bool, error) {
+	mergeRequest, _, err := c.client.MergeRequests.GetMergeRequest(c.projectPath(), number, nil)
+	if err != nil {
+		return false, err
+	}
+	return mergeRequest.State == "merged", nil
+}
+
+func (c *GitLabConnector) UpdateProposalBody(number int, body string) error {
+	if c.log != nil {
+		c.log("GitLab API: Updating description of MR !%d\n", number)
+	}
+	_, _, err := c.client.MergeRequests.UpdateMergeRequest(c.projectPath(), number, &gitlab.UpdateMergeRequestOptions{
+		Description: gitlab.String(body),
+	})
+	return err
+}
+
 func (c *GitLabConnector) UpdateProposalTarget(number int, target string) error {
 	if c.log != nil {
 		c.log("GitLab API: Updating target branch for MR !%d to %q\n", number, target)
@@ -79,12 +232,8 @@ func NewGitlabConnector(gitConfig gitTownConfig, log logFn) (*GitLabConnector, e
 	if url == nil || (url.Host != "gitlab.com" && hostingService != config.HostingServiceGitLab) {
 		return nil, nil //nolint:nilnil
 	}
-	gitlabConfig := GitLabConfig{CommonConfig{
-		APIToken:     gitConfig.GitLabToken(),
-		Hostname:     url.Host,
-		Organization: url.Org,
-		Repository:   url.Repo,
-	}}
+	apiToken := gitConfig.APIToken(config.HostingServiceGitLab, url.Host).Value
+	gitlabConfig := GitLabConfig{newCommonConfig(gitConfig, apiToken, url.Host, url)}
 	clientOptFunc := gitlab.WithBaseURL(gitlabConfig.baseURL())
 	httpClient := gitlab.WithHTTPClient(&http.Client{})
 	client, err := gitlab.NewOAuthClient(gitlabConfig.APIToken, httpClient, clientOptFunc)
@@ -111,10 +260,16 @@ func (c *GitLabConfig) DefaultProposalMessage(proposal Proposal) string {
 	return fmt.Sprintf("%s (!%d)", proposal.Title, proposal.Number)
 }
 
+// projectPath provides the path of the project that contains the merge requests.
 func (c *GitLabConfig) projectPath() string {
 	return fmt.Sprintf("%s/%s", c.Organization, c.Repository)
 }
 
+// originProjectPath provides the path of the project at the origin remote.
+func (c *GitLabConfig) originProjectPath() string {
+	return fmt.Sprintf("%s/%s", c.originOrganization(), c.originRepository())
+}
+
 func (c *GitLabConfig) baseURL() string {
 	return fmt.Sprintf("https://%s", c.Hostname)
 }
@@ -124,10 +279,11 @@ func (c *GitLabConfig) HostingServiceName() string {
 }
 
 func (c *GitLabConfig) NewProposalURL(branch, parentBranch string) (string, error) {
+	// GitLab offers to merge branches of forks into the project they were forked from
 	query := url.Values{}
 	query.Add("merge_request[source_branch]", branch)
 	query.Add("merge_request[target_branch]", parentBranch)
-	return fmt.Sprintf("%s/merge_requests/new?%s", c.RepositoryURL(), query.Encode()), nil
+	return fmt.Sprintf("%s/%s/merge_requests/new?%s", c.baseURL(), c.originProjectPath(), query.Encode()), nil
 }
 
 func (c *GitLabConfig) RepositoryURL() string {
@@ -138,11 +294,47 @@ func (c *GitLabConfig) RepositoryURL() string {
 // Helper functions
 // *************************************
 
+// gitlabAcceptOptions provides the options to merge a GitLab merge request with the given ship strategy and commit message.
+func gitlabAcceptOptions(strategy config.ShipStrategy, message string) (*gitlab.AcceptMergeRequestOptions, error) {
+	options := gitlab.AcceptMergeRequestOptions{
+		// the branch will be deleted by Git Town
+		ShouldRemoveSourceBranch: gitlab.Bool(false),
+	}
+	// the GitLab API wants the full commit message in the body
+	switch strategy {
+	case config.ShipStrategySquash:
+		options.Squash = gitlab.Bool(true)
+		options.SquashCommitMessage = gitlab.String(message)
+	case config.ShipStrategyMerge:
+		options.Squash = gitlab.Bool(false)
+		options.MergeCommitMessage = gitlab.String(message)
+	case config.ShipStrategyRebase, config.ShipStrategyFastForward:
+		// GitLab rebases and fast-forwards merge requests only if the project settings say so
+		return nil, unsupportedShipStrategyError("GitLab", strategy)
+	}
+	return &options, nil
+}
+
 func parseGitLabMergeRequest(mergeRequest *gitlab.MergeRequest) Proposal {
 	return Proposal{
+		Body:            mergeRequest.Description,
+		Draft:           mergeRequest.Draft || mergeRequest.WorkInProgress,
 		Number:          mergeRequest.IID,
 		Target:          mergeRequest.TargetBranch,
 		Title:           mergeRequest.Title,
+		URL:             mergeRequest.WebURL,
 		CanMergeWithAPI: true,
 	}
 }
+
+// parseGitLabPipelineStatus provides the CI status for the given status of a GitLab pipeline.
+func parseGitLabPipelineStatus(status string) CIStatus {
+	switch status {
+	case "success", "skipped":
+		return CIStatusSuccess
+	case "failed", "canceled":
+		return CIStatusFailure
+	default:
+		return CIStatusPending
+	}
+}
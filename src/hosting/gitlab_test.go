@@ -3,6 +3,7 @@ package hosting_test
 import (
 	"testing"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/hosting"
 	"github.com/stretchr/testify/assert"
 )
@@ -68,10 +69,31 @@ func TestNewGitlabConnector(t *testing.T) {
 		assert.Equal(t, "GitLab", connector.HostingServiceName())
 		assert.Equal(t, "https://gitlab.com/git-town/git-town", connector.RepositoryURL())
 	})
+
+	t.Run("fork of an upstream project", func(t *testing.T) {
+		t.Parallel()
+		repoConfig := mockRepoConfig{
+			originURL:   "git@gitlab.com:kevgo/git-town.git",
+			upstreamURL: "git@gitlab.com:git-town/git-town.git",
+		}
+		connector, err := hosting.NewGitlabConnector(repoConfig, nil)
+		assert.NoError(t, err)
+		assert.True(t, connector.IsFork())
+		assert.Equal(t, "https://gitlab.com/git-town/git-town", connector.RepositoryURL())
+		have, err := connector.NewProposalURL("feature", "main")
+		assert.NoError(t, err)
+		assert.Equal(t, "https://gitlab.com/kevgo/git-town/merge_requests/new?merge_request%5Bsource_branch%5D=feature&merge_request%5Btarget_branch%5D=main", have)
+	})
 }
 
 func TestGitlabConnector(t *testing.T) {
 	t.Parallel()
+	t.Run("AutoMergeProposal with unsupported strategy", func(t *testing.T) {
+		t.Parallel()
+		connector := hosting.GitLabConnector{} //nolint:exhaustruct
+		err := connector.AutoMergeProposal(1, config.ShipStrategyRebase, "")
+		assert.Error(t, err)
+	})
 	t.Run("DefaultProposalMessage", func(t *testing.T) {
 		t.Parallel()
 		config := hosting.GitLabConfig{}
@@ -111,7 +133,7 @@ func TestGitlabConnector(t *testing.T) {
 			t.Run(name, func(t *testing.T) {
 				connector := hosting.GitLabConnector{
 					GitLabConfig: hosting.GitLabConfig{
-						CommonConfig: hosting.CommonConfig{
+						CommonConfig: hosting.CommonConfig{ //nolint:exhaustruct
 							Hostname:     "gitlab.com",
 							Organization: "organization",
 							Repository:   "repo",
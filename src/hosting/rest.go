@@ -0,0 +1,70 @@
+package hosting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// restClient talks to JSON-based REST APIs of code hosting services
+// that don't have a Go SDK that Git Town uses.
+type restClient struct {
+	// authorize adds the authentication data to the given request
+	authorize func(*http.Request)
+
+	// name of the hosting service, used in error messages
+	serviceName string
+}
+
+// call sends the given payload encoded as JSON to the given endpoint
+// and decodes the JSON response into the given result, if any.
+func (rc restClient) call(method, endpoint string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("cannot encode %s API request: %w", rc.serviceName, err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequest(method, endpoint, body) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if rc.authorize != nil {
+		rc.authorize(request)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		content, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("unexpected response from the %s API for %s %s: %s %s", rc.serviceName, method, request.URL.Path, response.Status, strings.TrimSpace(string(content)))
+	}
+	if result == nil {
+		return nil
+	}
+	err = json.NewDecoder(response.Body).Decode(result)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s API response: %w", rc.serviceName, err)
+	}
+	return nil
+}
+
+// bearerAuth provides an authorize function for restClient that uses the given token as a bearer token.
+func bearerAuth(token string) func(*http.Request) {
+	return func(request *http.Request) {
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package hosting
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// stackSectionStart marks the beginning of the stack section in proposal bodies.
+	stackSectionStart = "<!-- git-town stack start -->"
+
+	// stackSectionEnd marks the end of the stack section in proposal bodies.
+	stackSectionEnd = "<!-- git-town stack end -->"
+)
+
+// StackSection provides the section of a proposal body that lists all proposals of a stack of branches
+// in the given order, highlighting the proposal at the given position.
+func StackSection(stack []Proposal, current int) string {
+	lines := []string{stackSectionStart, "This is part of a stack:"}
+	for p, proposal := range stack {
+		if p == current {
+			lines = append(lines, fmt.Sprintf("%d. **%s** (this one)", p+1, proposal.Title))
+		} else {
+			lines = append(lines, fmt.Sprintf("%d. [%s](%s)", p+1, proposal.Title, proposal.URL))
+		}
+	}
+	lines = append(lines, stackSectionEnd)
+	return strings.Join(lines, "\n")
+}
+
+// UpdateStackSection provides the given proposal body with the given stack section.
+// Replaces an existing stack section, otherwise appends the stack section to the body.
+func UpdateStackSection(body, section string) string {
+	start := strings.Index(body, stackSectionStart)
+	end := strings.Index(body, stackSectionEnd)
+	if start >= 0 && end > start {
+		return body[:start] + section + body[end+len(stackSectionEnd):]
+	}
+	body = strings.TrimRight(body, "\n ")
+	if body == "" {
+		return section
+	}
+	return body + "\n\n" + section
+}
@@ -0,0 +1,41 @@
+package hosting_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackSection(t *testing.T) {
+	t.Parallel()
+	stack := []hosting.Proposal{
+		{Number: 1, Title: "first", URL: "https://example.com/pulls/1"},  //nolint:exhaustruct
+		{Number: 2, Title: "second", URL: "https://example.com/pulls/2"}, //nolint:exhaustruct
+		{Number: 3, Title: "third", URL: "https://example.com/pulls/3"},  //nolint:exhaustruct
+	}
+	have := hosting.StackSection(stack, 1)
+	want := `<!-- git-town stack start -->
+This is part of a stack:
+1. [first](https://example.com/pulls/1)
+2. **second** (this one)
+3. [third](https://example.com/pulls/3)
+<!-- git-town stack end -->`
+	assert.Equal(t, want, have)
+}
+
+func TestUpdateStackSection(t *testing.T) {
+	t.Parallel()
+	section := "<!-- git-town stack start -->\nnew\n<!-- git-town stack end -->"
+	tests := map[string]string{
+		"":              section,
+		"description":   "description\n\n" + section,
+		"description\n": "description\n\n" + section,
+		"description\n\n<!-- git-town stack start -->\nold\n<!-- git-town stack end -->":              "description\n\n" + section,
+		"description\n\n<!-- git-town stack start -->\nold\n<!-- git-town stack end -->\n\nmore text": "description\n\n" + section + "\n\nmore text",
+	}
+	for give, want := range tests {
+		have := hosting.UpdateStackSection(give, section)
+		assert.Equal(t, want, have, give)
+	}
+}
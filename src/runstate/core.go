@@ -4,10 +4,14 @@
 package runstate
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/steps"
+	"github.com/kballard/go-shellquote"
 )
 
 // UnfinishedRunStateDetails has details about an unfinished run state.
@@ -15,17 +19,25 @@ type UnfinishedRunStateDetails struct {
 	CanSkip   bool
 	EndBranch string
 	EndTime   time.Time
+	// Interrupted indicates that the command didn't stop because of a problem
+	// but because Git Town got interrupted or terminated while running it.
+	Interrupted bool `exhaustruct:"optional"`
 }
 
 // RunState represents the current state of a Git Town command,
 // including which operations are left to do,
 // and how to undo what has been done so far.
 type RunState struct {
-	AbortStepList     StepList `exhaustruct:"optional"`
+	AbortStepList StepList `exhaustruct:"optional"`
+	// CanSkipBranches indicates that the command works on independent branches,
+	// so that the user can skip a branch that ran into a problem and continue with the remaining ones.
+	CanSkipBranches   bool `exhaustruct:"optional"`
 	Command           string
-	IsAbort           bool `exhaustruct:"optional"`
-	isUndo            bool `exhaustruct:"optional"`
+	CommandLine       string `exhaustruct:"optional"` // the arguments with which the user called Git Town
+	IsAbort           bool   `exhaustruct:"optional"`
+	isUndo            bool   `exhaustruct:"optional"`
 	RunStepList       StepList
+	SchemaVersion     int                        `exhaustruct:"optional"` // the format in which this run state is stored
 	UndoStepList      StepList                   `exhaustruct:"optional"`
 	UnfinishedDetails *UnfinishedRunStateDetails `exhaustruct:"optional"`
 }
@@ -34,6 +46,7 @@ type RunState struct {
 func New(command string, stepList StepList) *RunState {
 	return &RunState{
 		Command:     command,
+		CommandLine: shellquote.Join(os.Args[1:]...),
 		RunStepList: stepList,
 	}
 }
@@ -62,9 +75,12 @@ func (runState *RunState) AddPushBranchStepAfterCurrentBranchSteps(repo *git.Pro
 // CreateAbortRunState returns a new runstate
 // to be run to aborting and undoing the Git Town command
 // represented by this runstate.
+//
+// Steps that this version of Git Town cannot read are left out,
+// so that aborting restores the recorded branches and SHAs as well as possible.
 func (runState *RunState) CreateAbortRunState() RunState {
-	stepList := runState.AbortStepList
-	stepList.AppendList(runState.UndoStepList)
+	stepList := runState.AbortStepList.withoutUnknownSteps()
+	stepList.AppendList(runState.UndoStepList.withoutUnknownSteps())
 	return RunState{
 		Command:     runState.Command,
 		IsAbort:     true,
@@ -74,13 +90,18 @@ func (runState *RunState) CreateAbortRunState() RunState {
 
 // CreateSkipRunState returns a new Runstate
 // that skips operations for the current branch.
+// It keeps the undo steps for the other branches,
+// so that aborting or undoing it later still covers the entire command.
 func (runState *RunState) CreateSkipRunState() RunState {
 	result := RunState{
-		Command:     runState.Command,
-		RunStepList: runState.AbortStepList,
+		CanSkipBranches: runState.CanSkipBranches,
+		Command:         runState.Command,
+		CommandLine:     runState.CommandLine,
+		RunStepList:     runState.AbortStepList,
 	}
-	for _, step := range runState.UndoStepList.List {
+	for s, step := range runState.UndoStepList.List {
 		if isCheckoutStep(step) {
+			result.UndoStepList.AppendList(StepList{List: runState.UndoStepList.List[s:]})
 			break
 		}
 		result.RunStepList.Append(step)
@@ -104,7 +125,7 @@ func (runState *RunState) CreateUndoRunState() RunState {
 	return RunState{
 		Command:     runState.Command,
 		isUndo:      true,
-		RunStepList: runState.UndoStepList,
+		RunStepList: runState.UndoStepList.withoutUnknownSteps(),
 	}
 }
 
@@ -130,6 +151,17 @@ func (runState *RunState) MarkAsFinished() {
 	runState.UnfinishedDetails = nil
 }
 
+// MarkAsInterrupted updates the run state to be marked as interrupted, i.e. unfinished without a problem to resolve,
+// and populates informational fields.
+func (runState *RunState) MarkAsInterrupted(repo *git.ProdRepo) error {
+	err := runState.MarkAsUnfinished(repo)
+	if err != nil {
+		return err
+	}
+	runState.UnfinishedDetails.Interrupted = true
+	return nil
+}
+
 // MarkAsUnfinished updates the run state to be marked as unfinished and populates informational fields.
 func (runState *RunState) MarkAsUnfinished(repo *git.ProdRepo) error {
 	currentBranch, err := repo.Silent.CurrentBranch()
@@ -144,6 +176,14 @@ func (runState *RunState) MarkAsUnfinished(repo *git.ProdRepo) error {
 	return nil
 }
 
+// MarshalJSON stores this run state in the current schema version.
+func (runState *RunState) MarshalJSON() ([]byte, error) {
+	type storedRunState RunState // has no MarshalJSON method, which avoids infinite recursion
+	stored := storedRunState(*runState)
+	stored.SchemaVersion = CurrentSchemaVersion
+	return json.Marshal(&stored)
+}
+
 // SkipCurrentBranchSteps removes the steps for the current branch
 // from this run state.
 func (runState *RunState) SkipCurrentBranchSteps() {
@@ -156,6 +196,62 @@ func (runState *RunState) SkipCurrentBranchSteps() {
 	}
 }
 
+// UnknownSteps provides the type names of the steps in this run state
+// that this version of Git Town cannot read.
+func (runState *RunState) UnknownSteps() []string {
+	result := []string{}
+	for _, stepList := range []StepList{runState.AbortStepList, runState.RunStepList, runState.UndoStepList} {
+		for _, step := range stepList.List {
+			if unknownStep, isUnknown := step.(*steps.UnknownStep); isUnknown {
+				result = append(result, unknownStep.Type)
+			}
+		}
+	}
+	return result
+}
+
+// UnmarshalJSON loads a run state that the current or an older version of Git Town has stored.
+// It migrates run states from older schema versions to the current one.
+func (runState *RunState) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+	schemaVersion := 0
+	if storedVersion, has := fields["SchemaVersion"]; has {
+		err = json.Unmarshal(storedVersion, &schemaVersion)
+		if err != nil {
+			return fmt.Errorf("cannot parse the schema version of the run state: %w", err)
+		}
+	}
+	if schemaVersion > CurrentSchemaVersion {
+		return fmt.Errorf("a newer version of Git Town stored this run state (schema version %d), please upgrade Git Town or run \"git town status reset\" to delete it", schemaVersion)
+	}
+	for ; schemaVersion < CurrentSchemaVersion; schemaVersion++ {
+		err = migrations[schemaVersion].apply(fields)
+		if err != nil {
+			return fmt.Errorf("cannot migrate the run state from schema version %d: %w", schemaVersion, err)
+		}
+	}
+	fields["SchemaVersion"], err = json.Marshal(CurrentSchemaVersion)
+	if err != nil {
+		return err
+	}
+	migrated, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	type storedRunState RunState // has no UnmarshalJSON method, which avoids infinite recursion
+	var stored storedRunState
+	err = json.Unmarshal(migrated, &stored)
+	if err != nil {
+		return err
+	}
+	*runState = RunState(stored)
+	return nil
+}
+
 func isCheckoutStep(step steps.Step) bool {
 	return typeName(step) == "*CheckoutStep"
 }
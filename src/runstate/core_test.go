@@ -21,6 +21,7 @@ func TestRunState(t *testing.T) {
 			RunStepList: runstate.StepList{
 				List: []steps.Step{&steps.ResetToShaStep{Sha: "abc"}},
 			},
+			SchemaVersion: runstate.CurrentSchemaVersion,
 			UndoStepList: runstate.StepList{
 				List: []steps.Step{&steps.ResetToShaStep{Sha: "abc"}},
 			},
@@ -32,4 +33,98 @@ func TestRunState(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, runState, newRunState)
 	})
+	t.Run(".CreateSkipRunState()", func(t *testing.T) {
+		t.Parallel()
+		runState := &runstate.RunState{ //nolint:exhaustruct
+			AbortStepList: runstate.StepList{
+				List: []steps.Step{&steps.AbortMergeStep{}},
+			},
+			CanSkipBranches: true,
+			Command:         "ship",
+			RunStepList: runstate.StepList{
+				List: []steps.Step{
+					&steps.CheckoutParentStep{CheckoutStep: steps.CheckoutStep{Branch: "main"}},
+					&steps.SquashMergeStep{Branch: "beta"}, //nolint:exhaustruct
+					&steps.CheckoutStep{Branch: "gamma"},
+					&steps.MergeStep{Branch: "main"},
+				},
+			},
+			UndoStepList: runstate.StepList{
+				List: []steps.Step{
+					&steps.ResetToShaStep{Sha: "beta", Hard: true},
+					&steps.CheckoutStep{Branch: "main"},
+					&steps.RevertCommitStep{Sha: "alpha"},
+				},
+			},
+		}
+		have := runState.CreateSkipRunState()
+		assert.True(t, have.CanSkipBranches)
+		assert.Equal(t, []string{"*AbortMergeStep", "*ResetToShaStep", "*CheckoutStep", "*MergeStep"}, have.RunStepList.StepTypes())
+		assert.Equal(t, []string{"*CheckoutStep", "*RevertCommitStep"}, have.UndoStepList.StepTypes())
+	})
+	t.Run(".Unmarshal()", func(t *testing.T) {
+		t.Parallel()
+		t.Run("migrates run states without schema version", func(t *testing.T) {
+			t.Parallel()
+			give := `{
+				"Command": "ship",
+				"RunStepList": [
+					{"type": "*CheckoutBranchStep", "data": {"BranchName": "main"}},
+					{"type": "*DriverMergePullRequestStep", "data": {"BranchName": "feature", "CommitMessage": "done", "DefaultCommitMessage": "feature", "PullRequestNumber": 123}}
+				],
+				"UndoStepList": [
+					{"type": "*NoOpStep", "data": {}}
+				]
+			}`
+			have := runstate.RunState{} //nolint:exhaustruct
+			err := json.Unmarshal([]byte(give), &have)
+			assert.NoError(t, err)
+			want := runstate.RunState{ //nolint:exhaustruct
+				Command: "ship",
+				RunStepList: runstate.StepList{
+					List: []steps.Step{
+						&steps.CheckoutStep{Branch: "main"},
+						&steps.ConnectorMergeProposalStep{Branch: "feature", CommitMessage: "done", DefaultProposalMessage: "feature", ProposalNumber: 123}, //nolint:exhaustruct
+					},
+				},
+				SchemaVersion: runstate.CurrentSchemaVersion,
+				UndoStepList: runstate.StepList{
+					List: []steps.Step{&steps.EmptyStep{}},
+				},
+			}
+			assert.Equal(t, want, have)
+		})
+
+		t.Run("keeps unknown steps", func(t *testing.T) {
+			t.Parallel()
+			give := `{
+				"Command": "sync",
+				"RunStepList": [{"type": "*ZonkStep", "data": {"Branch": "feature"}}],
+				"SchemaVersion": 1,
+				"UndoStepList": [
+					{"type": "*ZonkStep", "data": {"Branch": "feature"}},
+					{"type": "*ResetToShaStep", "data": {"Hard": true, "Sha": "abc"}}
+				]
+			}`
+			runState := runstate.RunState{} //nolint:exhaustruct
+			err := json.Unmarshal([]byte(give), &runState)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"*ZonkStep", "*ZonkStep"}, runState.UnknownSteps())
+			abortRunState := runState.CreateAbortRunState()
+			assert.Equal(t, []string{"*ResetToShaStep"}, abortRunState.RunStepList.StepTypes())
+			data, err := json.Marshal(&runState)
+			assert.NoError(t, err)
+			stored := map[string]json.RawMessage{}
+			err = json.Unmarshal(data, &stored)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[{"type": "*ZonkStep", "data": {"Branch": "feature"}}]`, string(stored["RunStepList"]))
+		})
+
+		t.Run("run state from a newer Git Town version", func(t *testing.T) {
+			t.Parallel()
+			runState := runstate.RunState{} //nolint:exhaustruct
+			err := json.Unmarshal([]byte(`{"Command": "sync", "SchemaVersion": 999}`), &runState)
+			assert.Error(t, err)
+		})
+	})
 }
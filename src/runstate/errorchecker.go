@@ -53,6 +53,20 @@ func (ec *ErrorChecker) PullBranchStrategy(value config.PullBranchStrategy, err
 	return value
 }
 
+// ShipStrategy provides the config.ShipStrategy part of the given fallible function result
+// while registering the given error.
+func (ec *ErrorChecker) ShipStrategy(value config.ShipStrategy, err error) config.ShipStrategy {
+	ec.Check(err)
+	return value
+}
+
+// SquashAuthorMode provides the config.SquashAuthorMode part of the given fallible function result
+// while registering the given error.
+func (ec *ErrorChecker) SquashAuthorMode(value config.SquashAuthorMode, err error) config.SquashAuthorMode {
+	ec.Check(err)
+	return value
+}
+
 // String provides the string part of the given fallible function result
 // while registering the given error.
 func (ec *ErrorChecker) String(value string, err error) string {
@@ -6,16 +6,39 @@ import (
 	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/steps"
 )
 
 // Execute runs the commands in the given runstate.
+// It stores the run state after each step, so that the user can continue, abort, or undo the command
+// if Git Town gets interrupted or terminated while running it.
+// It holds the lock for the repository while running the commands.
 //
 //nolint:nestif
 func Execute(runState *RunState, repo *git.ProdRepo, connector hosting.Connector) error {
+	if repo.DryRun.IsActive() && connector != nil {
+		connector = hosting.NewDryRunConnector(connector, cli.PrintConnectorAction)
+	}
+	lock, err := AcquireLock(repo)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+	interrupts := handleInterrupts()
+	defer interrupts.stop()
+	err = saveProgress(runState, repo)
+	if err != nil {
+		return err
+	}
 	for {
 		step := runState.RunStepList.Pop()
 		if step == nil {
 			runState.MarkAsFinished()
+			if repo.DryRun.IsActive() {
+				// a dry run doesn't change the repository, there is nothing to undo
+				cli.Println()
+				return nil
+			}
 			if runState.IsAbort || runState.isUndo {
 				err := Delete(repo)
 				if err != nil {
@@ -26,8 +49,12 @@ func Execute(runState *RunState, repo *git.ProdRepo, connector hosting.Connector
 				if err != nil {
 					return fmt.Errorf("cannot save run state: %w", err)
 				}
+				err = AddToJournal(runState, repo)
+				if err != nil {
+					return fmt.Errorf("cannot add the command to the undo journal: %w", err)
+				}
 			}
-			fmt.Println()
+			cli.Println()
 			return nil
 		}
 		if typeName(step) == "*SkipCurrentBranchSteps" {
@@ -41,7 +68,11 @@ func Execute(runState *RunState, repo *git.ProdRepo, connector hosting.Connector
 			}
 			continue
 		}
+		cli.EmitEvent("step", cli.Event{"step": &JSONStep{Step: step}})
 		runErr := step.Run(repo, connector)
+		if interrupts.interrupted() {
+			return stopInterrupted(runState, step, runErr, repo)
+		}
 		if runErr != nil {
 			runState.AbortStepList.Append(step.CreateAbortStep())
 			if step.ShouldAutomaticallyAbortOnError() {
@@ -51,8 +82,12 @@ func Execute(runState *RunState, repo *git.ProdRepo, connector hosting.Connector
 				if err != nil {
 					return fmt.Errorf("cannot run the abort steps: %w", err)
 				}
+				lock.Release()
 				cli.Exit(step.CreateAutomaticAbortError())
 			} else {
+				if repo.DryRun.IsActive() {
+					return runErr
+				}
 				runState.RunStepList.Prepend(step.CreateContinueStep())
 				err := runState.MarkAsUnfinished(repo)
 				if err != nil {
@@ -69,6 +104,9 @@ func Execute(runState *RunState, repo *git.ProdRepo, connector hosting.Connector
 				if runState.Command == "sync" && !(rebasing && repo.Config.IsMainBranch(currentBranch)) {
 					runState.UnfinishedDetails.CanSkip = true
 				}
+				if runState.CanSkipBranches && repo.Config.IsFeatureBranch(currentBranch) {
+					runState.UnfinishedDetails.CanSkip = true
+				}
 				err = Save(runState, repo)
 				if err != nil {
 					return fmt.Errorf("cannot save run state: %w", err)
@@ -82,7 +120,11 @@ To continue after having resolved conflicts, run "git-town continue".
 					message += `To continue by skipping the current branch, run "git-town skip".`
 				}
 				message += "\n"
-				return fmt.Errorf(message)
+				return UnfinishedError{
+					command: runState.Command,
+					details: *runState.UnfinishedDetails,
+					message: message,
+				}
 			}
 		}
 		undoStep, err := step.CreateUndoStep(repo)
@@ -90,5 +132,101 @@ To continue after having resolved conflicts, run "git-town continue".
 			return fmt.Errorf("cannot create undo step for %q: %w", step, err)
 		}
 		runState.UndoStepList.Prepend(undoStep)
+		err = saveProgress(runState, repo)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// saveProgress stores the given run state of a command that is still running.
+// If Git Town gets terminated before the command finishes, the stored run state marks it as interrupted.
+func saveProgress(runState *RunState, repo *git.ProdRepo) error {
+	if repo.DryRun.IsActive() {
+		return nil
+	}
+	err := runState.MarkAsInterrupted(repo)
+	if err != nil {
+		return err
+	}
+	err = Save(runState, repo)
+	if err != nil {
+		return fmt.Errorf("cannot save run state: %w", err)
+	}
+	return nil
+}
+
+// stopInterrupted stores the given run state of a command that got interrupted
+// after running the given step, which ended with the given error,
+// so that the user can continue, abort, or undo it.
+func stopInterrupted(runState *RunState, step steps.Step, runErr error, repo *git.ProdRepo) error {
+	if runErr == nil {
+		undoStep, err := step.CreateUndoStep(repo)
+		if err != nil {
+			return fmt.Errorf("cannot create undo step for %q: %w", step, err)
+		}
+		runState.UndoStepList.Prepend(undoStep)
+	} else {
+		runState.AbortStepList.Append(step.CreateAbortStep())
+		hasMergeInProgress, err := repo.Silent.HasMergeInProgress()
+		if err != nil {
+			return err
+		}
+		hasRebaseInProgress, err := repo.Silent.HasRebaseInProgress()
+		if err != nil {
+			return err
+		}
+		if hasMergeInProgress || hasRebaseInProgress {
+			runState.RunStepList.Prepend(step.CreateContinueStep())
+		} else {
+			// the interruption stopped the step before it made changes, run it again when continuing
+			runState.RunStepList.Prepend(step)
+		}
+	}
+	if repo.DryRun.IsActive() {
+		return fmt.Errorf("interrupted the %q command", runState.Command)
+	}
+	err := runState.MarkAsInterrupted(repo)
+	if err != nil {
+		return err
+	}
+	err = Save(runState, repo)
+	if err != nil {
+		return fmt.Errorf("cannot save run state: %w", err)
+	}
+	return UnfinishedError{
+		command: runState.Command,
+		details: *runState.UnfinishedDetails,
+		message: fmt.Sprintf(`interrupted the %q command
+
+To continue it, run "git-town continue".
+To abort it, run "git-town abort".
+To undo what it did so far, run "git-town undo".
+`, runState.Command),
+	}
+}
+
+// UnfinishedError signals that a Git Town command stopped
+// and left an unfinished run state behind that the user can continue, skip, or abort.
+type UnfinishedError struct {
+	command string
+	details UnfinishedRunStateDetails
+	message string
+}
+
+func (e UnfinishedError) Error() string {
+	return e.message
+}
+
+// EventFields provides the machine-readable description of the unfinished run state.
+func (e UnfinishedError) EventFields() cli.Event {
+	return cli.Event{
+		"unfinished": cli.Event{
+			"canSkip":     e.details.CanSkip,
+			"command":     e.command,
+			"endBranch":   e.details.EndBranch,
+			"endTime":     e.details.EndTime,
+			"interrupted": e.details.Interrupted,
+		},
 	}
 }
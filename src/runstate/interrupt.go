@@ -0,0 +1,40 @@
+package runstate
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptHandler intercepts the signals that interrupt or terminate Git Town,
+// so that the running command can finish its current step and store its state
+// before it stops.
+type interruptHandler struct {
+	received bool
+	signals  chan os.Signal
+}
+
+// handleInterrupts starts intercepting the signals that interrupt or terminate Git Town.
+func handleInterrupts() *interruptHandler {
+	handler := interruptHandler{
+		received: false,
+		signals:  make(chan os.Signal, 1),
+	}
+	signal.Notify(handler.signals, os.Interrupt, syscall.SIGTERM)
+	return &handler
+}
+
+// interrupted indicates whether Git Town has been interrupted or terminated since this handler started.
+func (handler *interruptHandler) interrupted() bool {
+	select {
+	case <-handler.signals:
+		handler.received = true
+	default:
+	}
+	return handler.received
+}
+
+// stop ends intercepting signals.
+func (handler *interruptHandler) stop() {
+	signal.Stop(handler.signals)
+}
@@ -0,0 +1,213 @@
+package runstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/steps"
+	"github.com/git-town/git-town/v7/src/stringslice"
+)
+
+// maxJournalEntries is the number of finished Git Town commands that the journal remembers.
+const maxJournalEntries = 20
+
+// Journal contains the most recently finished Git Town commands in a repository, newest first.
+// It allows undoing several commands in the order in which they ran.
+type Journal struct {
+	Entries []JournalEntry
+}
+
+// JournalEntry is a finished Git Town command in the Journal.
+type JournalEntry struct {
+	// BranchShas contains the SHAs of the branches that undoing this command resets, at the time the command finished.
+	BranchShas map[string]string
+	EndBranch  string
+	EndTime    time.Time
+	RunState   RunState
+}
+
+// Description provides a human-readable description of this journal entry.
+func (entry *JournalEntry) Description() string {
+	commandLine := entry.RunState.CommandLine
+	if commandLine == "" {
+		commandLine = entry.RunState.Command
+	}
+	return "git town " + commandLine
+}
+
+// EnsureIsUndoable signals an error if undoing this journal entry would discard changes
+// that were made after the command finished, or reset the wrong branch.
+func (entry *JournalEntry) EnsureIsUndoable(repo *git.ProdRepo) error {
+	_, resetsEndBranchFirst := resetBranches(entry.RunState.UndoStepList, entry.EndBranch)
+	if resetsEndBranchFirst {
+		currentBranch, err := repo.Silent.CurrentBranch()
+		if err != nil {
+			return err
+		}
+		if currentBranch != entry.EndBranch {
+			return fmt.Errorf("cannot undo %q because it ended on branch %q but the current branch is %q", entry.Description(), entry.EndBranch, currentBranch)
+		}
+	}
+	branches := make([]string, 0, len(entry.BranchShas))
+	for branch := range entry.BranchShas {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	for _, branch := range branches {
+		hasBranch, err := repo.Silent.HasLocalBranch(branch)
+		if err != nil {
+			return err
+		}
+		if !hasBranch {
+			return fmt.Errorf("cannot undo %q because branch %q no longer exists", entry.Description(), branch)
+		}
+		currentSha, err := repo.Silent.ShaForBranch(branch)
+		if err != nil {
+			return err
+		}
+		if currentSha != entry.BranchShas[branch] {
+			return fmt.Errorf("cannot undo %q because branch %q has changed since then, undoing would reset these changes", entry.Description(), branch)
+		}
+	}
+	return nil
+}
+
+// LoadJournal loads the journal for the given Git repo from disk.
+func LoadJournal(repo *git.ProdRepo) (*Journal, error) {
+	filename, err := JournalFilePath(repo)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Journal{Entries: []JournalEntry{}}, nil
+		}
+		return nil, fmt.Errorf("cannot read file %q: %w", filename, err)
+	}
+	var journal Journal
+	err = json.Unmarshal(content, &journal)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse content of file %q: %w", filename, err)
+	}
+	return &journal, nil
+}
+
+// SaveJournal stores the given journal for the given Git repo to disk.
+func SaveJournal(journal *Journal, repo *git.ProdRepo) error {
+	content, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode journal: %w", err)
+	}
+	filename, err := JournalFilePath(repo)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(filename), 0o700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filename, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write file %q: %w", filename, err)
+	}
+	return nil
+}
+
+// AddToJournal records the given finished run state as the newest entry in the journal of the given Git repo.
+func AddToJournal(runState *RunState, repo *git.ProdRepo) error {
+	journal, err := LoadJournal(repo)
+	if err != nil {
+		return err
+	}
+	endBranch, err := repo.Silent.CurrentBranch()
+	if err != nil {
+		return err
+	}
+	branches, _ := resetBranches(runState.UndoStepList, endBranch)
+	branchShas := map[string]string{}
+	for _, branch := range branches {
+		// branches that don't exist anymore get recreated when undoing the command
+		hasBranch, err := repo.Silent.HasLocalBranch(branch)
+		if err != nil {
+			return err
+		}
+		if !hasBranch {
+			continue
+		}
+		branchShas[branch], err = repo.Silent.ShaForBranch(branch)
+		if err != nil {
+			return err
+		}
+	}
+	entry := JournalEntry{
+		BranchShas: branchShas,
+		EndBranch:  endBranch,
+		EndTime:    time.Now(),
+		RunState:   *runState,
+	}
+	journal.Entries = append([]JournalEntry{entry}, journal.Entries...)
+	if len(journal.Entries) > maxJournalEntries {
+		journal.Entries = journal.Entries[:maxJournalEntries]
+	}
+	return SaveJournal(journal, repo)
+}
+
+// RemoveLatestFromJournal removes the newest entry from the journal of the given Git repo.
+func RemoveLatestFromJournal(repo *git.ProdRepo) error {
+	journal, err := LoadJournal(repo)
+	if err != nil {
+		return err
+	}
+	if len(journal.Entries) == 0 {
+		return nil
+	}
+	journal.Entries = journal.Entries[1:]
+	return SaveJournal(journal, repo)
+}
+
+// JournalFilePath provides the path of the file that stores the journal for the given Git repo.
+func JournalFilePath(repo *git.ProdRepo) (string, error) {
+	runStatePath, err := PersistenceFilePath(repo)
+	if err != nil {
+		return "", err
+	}
+	return journalPath(runStatePath), nil
+}
+
+// journalPath provides the path of the journal file that belongs to the run state file with the given path.
+func journalPath(runStatePath string) string {
+	return strings.TrimSuffix(runStatePath, ".json") + "-journal.json"
+}
+
+// resetBranches provides the branches whose commits the given undo steps reset,
+// when starting on the given branch,
+// and whether the given branch gets reset before any other branch is checked out.
+//
+//nolint:nonamedreturns  // return values aren't obvious from the function signature
+func resetBranches(undoSteps StepList, startBranch string) (branches []string, resetsStartBranchFirst bool) {
+	branches = []string{}
+	currentBranch := startBranch
+	checkedOut := false
+	for _, step := range undoSteps.List {
+		switch typedStep := step.(type) {
+		case *steps.CheckoutStep:
+			currentBranch = typedStep.Branch
+			checkedOut = true
+		case *steps.ResetToShaStep:
+			if !checkedOut {
+				resetsStartBranchFirst = true
+			}
+			if !stringslice.Contains(branches, currentBranch) {
+				branches = append(branches, currentBranch)
+			}
+		}
+	}
+	return branches, resetsStartBranchFirst
+}
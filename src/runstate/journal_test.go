@@ -0,0 +1,29 @@
+package runstate_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/runstate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalEntry(t *testing.T) {
+	t.Parallel()
+	t.Run("Description", func(t *testing.T) {
+		t.Parallel()
+		t.Run("with command line", func(t *testing.T) {
+			t.Parallel()
+			entry := runstate.JournalEntry{ //nolint:exhaustruct
+				RunState: runstate.RunState{Command: "ship", CommandLine: "ship -m 'feature done'"}, //nolint:exhaustruct
+			}
+			assert.Equal(t, "git town ship -m 'feature done'", entry.Description())
+		})
+		t.Run("without command line", func(t *testing.T) {
+			t.Parallel()
+			entry := runstate.JournalEntry{ //nolint:exhaustruct
+				RunState: runstate.RunState{Command: "sync"}, //nolint:exhaustruct
+			}
+			assert.Equal(t, "git town sync", entry.Description())
+		})
+	})
+}
@@ -15,6 +15,13 @@ type JSONStep struct {
 
 // MarshalJSON marshals the step to JSON.
 func (j *JSONStep) MarshalJSON() ([]byte, error) {
+	if unknownStep, isUnknown := j.Step.(*steps.UnknownStep); isUnknown {
+		// store unknown steps exactly as they were loaded
+		return json.Marshal(map[string]interface{}{
+			"data": unknownStep.Data,
+			"type": unknownStep.Type,
+		})
+	}
 	return json.Marshal(map[string]interface{}{
 		"data": j.Step,
 		"type": typeName(j.Step),
@@ -22,22 +29,29 @@ func (j *JSONStep) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON unmarshals the step from JSON.
+// Steps with an unknown type or unreadable data become UnknownSteps.
 func (j *JSONStep) UnmarshalJSON(b []byte) error {
-	var mapping map[string]*json.RawMessage
+	var mapping map[string]json.RawMessage
 	err := json.Unmarshal(b, &mapping)
 	if err != nil {
 		return err
 	}
 	var stepType string
-	err = json.Unmarshal(*mapping["type"], &stepType)
+	err = json.Unmarshal(mapping["type"], &stepType)
 	if err != nil {
-		return err
+		return fmt.Errorf("cannot parse the type of a stored step: %w", err)
 	}
+	unknownStep := steps.UnknownStep{Data: mapping["data"], Type: stepType}
 	j.Step = determineStep(stepType)
 	if j.Step == nil {
-		return fmt.Errorf("unknown step type: %q, run \"git town config runstate reset\" to reset it", stepType)
+		j.Step = &unknownStep
+		return nil
 	}
-	return json.Unmarshal(*mapping["data"], &j.Step)
+	err = json.Unmarshal(mapping["data"], &j.Step)
+	if err != nil {
+		j.Step = &unknownStep
+	}
+	return nil
 }
 
 func determineStep(stepType string) steps.Step {
@@ -48,8 +62,16 @@ func determineStep(stepType string) steps.Step {
 		return &steps.AbortRebaseStep{}
 	case "*AddToPerennialBranchesStep":
 		return &steps.AddToPerennialBranchesStep{}
+	case "*CheckoutParentStep":
+		return &steps.CheckoutParentStep{}
 	case "*CheckoutStep":
 		return &steps.CheckoutStep{}
+	case "*CloseProposalStep":
+		return &steps.CloseProposalStep{}
+	case "*ConnectorAutoMergeProposalStep":
+		return &steps.ConnectorAutoMergeProposalStep{}
+	case "*ConnectorCancelAutoMergeStep":
+		return &steps.ConnectorCancelAutoMergeStep{}
 	case "*ConnectorMergeProposalStep":
 		return &steps.ConnectorMergeProposalStep{}
 	case "*ContinueMergeStep":
@@ -64,6 +86,8 @@ func determineStep(stepType string) steps.Step {
 		return &steps.CreateRemoteBranchStep{}
 	case "*CreateTrackingBranchStep":
 		return &steps.CreateTrackingBranchStep{}
+	case "*DeleteAutoMergeProposalStep":
+		return &steps.DeleteAutoMergeProposalStep{}
 	case "*DeleteLocalBranchStep":
 		return &steps.DeleteLocalBranchStep{}
 	case "*DeleteOriginBranchStep":
@@ -76,8 +100,12 @@ func determineStep(stepType string) steps.Step {
 		return &steps.EmptyStep{}
 	case "*EnsureHasShippableChangesStep":
 		return &steps.EnsureHasShippableChangesStep{}
+	case "*FastForwardStep":
+		return &steps.FastForwardStep{}
 	case "*FetchUpstreamStep":
 		return &steps.FetchUpstreamStep{}
+	case "*MergeNoFastForwardStep":
+		return &steps.MergeNoFastForwardStep{}
 	case "*MergeStep":
 		return &steps.MergeStep{}
 	case "*PreserveCheckoutHistoryStep":
@@ -98,8 +126,18 @@ func determineStep(stepType string) steps.Step {
 		return &steps.ResetToShaStep{}
 	case "*RestoreOpenChangesStep":
 		return &steps.RestoreOpenChangesStep{}
+	case "*RestoreProposalBodiesStep":
+		return &steps.RestoreProposalBodiesStep{}
 	case "*RevertCommitStep":
 		return &steps.RevertCommitStep{}
+	case "*RevertCommitsStep":
+		return &steps.RevertCommitsStep{}
+	case "*RevertMergeCommitStep":
+		return &steps.RevertMergeCommitStep{}
+	case "*RevertProposalMergeStep":
+		return &steps.RevertProposalMergeStep{}
+	case "*SetAutoMergeProposalStep":
+		return &steps.SetAutoMergeProposalStep{}
 	case "*SetParentStep":
 		return &steps.SetParentStep{}
 	case "*SquashMergeStep":
@@ -108,6 +146,10 @@ func determineStep(stepType string) steps.Step {
 		return &steps.SkipCurrentBranchSteps{}
 	case "*StashOpenChangesStep":
 		return &steps.StashOpenChangesStep{}
+	case "*UpdateProposalStackStep":
+		return &steps.UpdateProposalStackStep{}
+	case "*UpdateProposalTargetStep":
+		return &steps.UpdateProposalTargetStep{}
 	}
 	return nil
 }
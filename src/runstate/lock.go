@@ -0,0 +1,229 @@
+package runstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/kballard/go-shellquote"
+)
+
+// lockPollInterval is how often Git Town checks whether the lock became free while waiting for it.
+const lockPollInterval = 100 * time.Millisecond
+
+// unreadableLockTimeout is how long Git Town considers a lock file that contains no readable information valid.
+// Lock files are unreadable while the process acquiring the lock is writing them.
+const unreadableLockTimeout = 10 * time.Second
+
+// waitForLock indicates whether Git Town waits for other Git Town commands to finish
+// instead of failing when they hold the lock for the repository.
+var waitForLock bool //nolint:gochecknoglobals
+
+// WaitForLock makes Git Town wait until other Git Town commands in the same repository finish
+// instead of failing.
+func WaitForLock(wait bool) {
+	waitForLock = wait
+}
+
+// Lock is an advisory lock that prevents several Git Town processes
+// from running commands in the same repository at the same time.
+type Lock struct {
+	// owned indicates whether this Lock created the lock file.
+	// A process that acquires the lock it already holds doesn't own the lock file.
+	owned bool
+	path  string
+}
+
+// lockHolder describes the Git Town process that holds a lock.
+type lockHolder struct {
+	Command   string
+	Hostname  string
+	PID       int
+	StartTime time.Time
+}
+
+// AcquireLock acquires the lock for the given repo.
+// Acquiring the lock again in the process that holds it succeeds.
+// If another Git Town process holds the lock, it fails or waits until that process releases the lock.
+// Locks of Git Town processes on this machine that don't exist anymore are stale and get removed.
+func AcquireLock(repo *git.ProdRepo) (*Lock, error) {
+	path, err := LockFilePath(repo)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine the hostname: %w", err)
+	}
+	me := lockHolder{
+		Command:   "git town " + shellquote.Join(os.Args[1:]...),
+		Hostname:  hostname,
+		PID:       os.Getpid(),
+		StartTime: time.Now(),
+	}
+	waiting := false
+	for {
+		err = createLockFile(path, me)
+		if err == nil {
+			return &Lock{owned: true, path: path}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		holder, isStale, err := loadLockHolder(path, hostname)
+		if err != nil {
+			return nil, err
+		}
+		if isStale {
+			err = removeStaleLock(path, holder, hostname)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if holder != nil && holder.Hostname == me.Hostname && holder.PID == me.PID {
+			return &Lock{owned: false, path: path}, nil
+		}
+		if !waitForLock {
+			return nil, lockedError(holder, path)
+		}
+		if !waiting {
+			cli.Printf("Waiting for %s to finish ...\n", holder.description())
+			waiting = true
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// LockFilePath provides the path of the lock file for the given Git repo.
+func LockFilePath(repo *git.ProdRepo) (string, error) {
+	runStatePath, err := PersistenceFilePath(repo)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(runStatePath, ".json") + ".lock", nil
+}
+
+// Release releases this lock.
+// If removing the lock file fails, the next Git Town command detects it as stale.
+func (lock *Lock) Release() {
+	if lock.owned {
+		_ = os.Remove(lock.path)
+	}
+}
+
+// createLockFile creates the lock file with the given path for the given holder.
+// Fails with os.ErrExist if the lock file already exists.
+func createLockFile(path string, holder lockHolder) error {
+	content, err := json.Marshal(holder)
+	if err != nil {
+		return fmt.Errorf("cannot encode lock: %w", err)
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(content)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("cannot write file %q: %w", path, err)
+	}
+	return file.Close()
+}
+
+// loadLockHolder provides the process holding the lock with the given lock file
+// and whether that lock is stale, from the perspective of the given host.
+// The holder is nil if the lock file doesn't contain readable information.
+//
+//nolint:nonamedreturns  // return values aren't obvious from the function signature
+func loadLockHolder(path, hostname string) (holder *lockHolder, isStale bool, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the lock got released in the meantime
+			return nil, true, nil
+		}
+		return nil, false, fmt.Errorf("cannot read file %q: %w", path, err)
+	}
+	var result lockHolder
+	err = json.Unmarshal(content, &result)
+	if err != nil {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, os.IsNotExist(err), nil //nolint:nilerr
+		}
+		return nil, time.Since(info.ModTime()) > unreadableLockTimeout, nil
+	}
+	// Git Town can only check whether processes on the same machine still exist
+	isStale = result.Hostname == hostname && !processExists(result.PID)
+	return &result, isStale, nil
+}
+
+// removeStaleLock removes the lock file with the given path if it still belongs to the given stale holder.
+// Several waiting processes can detect the same stale lock at the same time.
+// Once one of them has replaced it with its own lock, the others must not remove that new lock.
+// This therefore moves the lock file out of the way atomically, verifies that it is still the stale lock,
+// and puts it back otherwise.
+func removeStaleLock(path string, staleHolder *lockHolder, hostname string) error {
+	movedPath := fmt.Sprintf("%s.stale-%d", path, os.Getpid())
+	err := os.Rename(path, movedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// another process has removed the stale lock already
+			return nil
+		}
+		return fmt.Errorf("cannot delete stale lock file %q: %w", path, err)
+	}
+	movedHolder, isStale, err := loadLockHolder(movedPath, hostname)
+	if err != nil {
+		return err
+	}
+	if isStale && sameLockHolder(movedHolder, staleHolder) {
+		return os.Remove(movedPath)
+	}
+	// the moved lock file is a valid lock that another process has created in the meantime,
+	// restore it unless yet another process has created a lock file since
+	err = os.Link(movedPath, path)
+	if err != nil && !os.IsExist(err) {
+		return fmt.Errorf("cannot restore lock file %q: %w", path, err)
+	}
+	return os.Remove(movedPath)
+}
+
+// sameLockHolder indicates whether the given lock holders describe the same process.
+// Unreadable lock files have no holder.
+func sameLockHolder(holder1, holder2 *lockHolder) bool {
+	if holder1 == nil || holder2 == nil {
+		return holder1 == holder2
+	}
+	return holder1.Hostname == holder2.Hostname && holder1.PID == holder2.PID && holder1.StartTime.Equal(holder2.StartTime)
+}
+
+// description provides a human-readable description of the process holding a lock.
+func (holder *lockHolder) description() string {
+	if holder == nil {
+		return "another Git Town command"
+	}
+	return fmt.Sprintf("%q (process %d on %s)", holder.Command, holder.PID, holder.Hostname)
+}
+
+// lockedError provides the error for a lock with the given lock file held by the given process.
+func lockedError(holder *lockHolder, path string) error {
+	instructions := fmt.Sprintf(`To wait until it finishes, run your command again with "--wait".
+If it doesn't run anymore, delete %q.`, path)
+	if holder == nil {
+		return fmt.Errorf("another Git Town command is running in this repository\n\n%s", instructions)
+	}
+	return fmt.Errorf("another Git Town command (%q) is running in this repository\n\nIt runs as process %d on %s since %s.\n%s",
+		holder.Command, holder.PID, holder.Hostname, holder.StartTime.Format(time.RFC1123), instructions)
+}
@@ -0,0 +1,107 @@
+package runstate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentSchemaVersion is the version of the format in which Git Town stores run states.
+// When renaming or removing steps or their fields, increment it
+// and add a migration that converts run states stored in the previous format.
+const CurrentSchemaVersion = 1
+
+// migrations converts stored run states to the current schema version.
+// The migration at index i converts a run state from schema version i to version i+1.
+//
+//nolint:gochecknoglobals
+var migrations = []migration{
+	// schema version 0 is the unversioned format of Git Town releases that didn't version their run states
+	{
+		renamedSteps: map[string]string{
+			"*CheckoutBranchStep":         "*CheckoutStep",
+			"*CreatePullRequestStep":      "*CreateProposalStep",
+			"*DriverMergePullRequestStep": "*ConnectorMergeProposalStep",
+			"*NoOpStep":                   "*EmptyStep",
+		},
+		renamedFields: map[string]map[string]string{
+			allSteps: {
+				"BranchName": "Branch",
+			},
+			"*ConnectorMergeProposalStep": {
+				"DefaultCommitMessage": "DefaultProposalMessage",
+				"PullRequestNumber":    "ProposalNumber",
+			},
+		},
+	},
+}
+
+// allSteps is the key in migration.renamedFields for field renames that apply to all steps.
+const allSteps = "*"
+
+// migration describes how to convert a stored run state to the next schema version.
+type migration struct {
+	// renamedSteps maps the previous type names of renamed steps to their new type names
+	renamedSteps map[string]string
+	// renamedFields maps the new type names of steps to the previous and new names of their renamed fields
+	renamedFields map[string]map[string]string
+}
+
+// apply converts the given fields of a stored run state with this migration.
+func (m migration) apply(runState map[string]json.RawMessage) error {
+	for _, listName := range []string{"AbortStepList", "RunStepList", "UndoStepList"} {
+		list, has := runState[listName]
+		if !has {
+			continue
+		}
+		var storedSteps []map[string]json.RawMessage
+		err := json.Unmarshal(list, &storedSteps)
+		if err != nil {
+			return fmt.Errorf("cannot parse %s: %w", listName, err)
+		}
+		for s := range storedSteps {
+			err = m.applyToStep(storedSteps[s])
+			if err != nil {
+				return err
+			}
+		}
+		runState[listName], err = json.Marshal(storedSteps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyToStep converts the given fields of a stored step with this migration.
+func (m migration) applyToStep(step map[string]json.RawMessage) error {
+	var stepType string
+	err := json.Unmarshal(step["type"], &stepType)
+	if err != nil {
+		return fmt.Errorf("cannot parse the type of a stored step: %w", err)
+	}
+	if newType, renamed := m.renamedSteps[stepType]; renamed {
+		stepType = newType
+		step["type"], err = json.Marshal(stepType)
+		if err != nil {
+			return err
+		}
+	}
+	var data map[string]json.RawMessage
+	err = json.Unmarshal(step["data"], &data)
+	if err != nil || data == nil {
+		// leave steps without data, or with data this migration doesn't understand, as they are
+		return nil //nolint:nilerr
+	}
+	for _, renames := range []map[string]string{m.renamedFields[allSteps], m.renamedFields[stepType]} {
+		for oldName, newName := range renames {
+			value, has := data[oldName]
+			if !has {
+				continue
+			}
+			delete(data, oldName)
+			data[newName] = value
+		}
+	}
+	step["data"], err = json.Marshal(data)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/git"
 )
 
@@ -71,14 +72,56 @@ func Save(runState *RunState, repo *git.ProdRepo) error {
 	if err != nil {
 		return err
 	}
-	err = os.WriteFile(persistencePath, content, 0o600)
+	// write to a temporary file first so that getting terminated while writing doesn't corrupt the run state
+	tempPath := persistencePath + ".tmp"
+	err = os.WriteFile(tempPath, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write file %q: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, persistencePath)
 	if err != nil {
 		return fmt.Errorf("cannot write file %q: %w", persistencePath, err)
 	}
 	return nil
 }
 
+// PersistenceFilePath provides the path of the file that stores the run state for the given Git repo.
+// By default this file is in the Git directory of the current worktree,
+// so that each worktree has its own run state that moves together with the repository.
+// Read-only checkouts can store it in the user's configuration directory instead.
+// Run state files from the configuration directory get moved into the Git directory automatically.
 func PersistenceFilePath(repo *git.ProdRepo) (string, error) {
+	location, err := repo.Config.RunstateLocation()
+	if err != nil {
+		return "", err
+	}
+	if location == config.RunstateLocationConfigDir {
+		return legacyPersistenceFilePath(repo)
+	}
+	gitDir, err := repo.Silent.GitDirectory()
+	if err != nil {
+		return "", err
+	}
+	persistencePath := filepath.Join(gitDir, "git-town", "runstate.json")
+	legacyPath, err := legacyPersistenceFilePath(repo)
+	if err != nil {
+		// without a configuration directory there are no files to migrate
+		return persistencePath, nil //nolint:nilerr
+	}
+	err = migrateFile(legacyPath, persistencePath)
+	if err != nil {
+		return "", err
+	}
+	err = migrateFile(journalPath(legacyPath), journalPath(persistencePath))
+	if err != nil {
+		return "", err
+	}
+	return persistencePath, nil
+}
+
+// legacyPersistenceFilePath provides the path of the file that stores the run state for the given Git repo
+// in the user's configuration directory.
+func legacyPersistenceFilePath(repo *git.ProdRepo) (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
@@ -89,10 +132,44 @@ func PersistenceFilePath(repo *git.ProdRepo) (string, error) {
 		return "", err
 	}
 	filename := SanitizePath(repoDir)
+	return filepath.Join(persistenceDir, filename+".json"), nil
+}
+
+// migrateFile moves the file at the given old path to the given new path
+// if it exists and there is no file at the new path yet.
+func migrateFile(oldPath, newPath string) error {
+	_, err := os.Stat(oldPath)
 	if err != nil {
-		return "", err
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot check file %q: %w", oldPath, err)
 	}
-	return filepath.Join(persistenceDir, filename+".json"), nil
+	_, err = os.Stat(newPath)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("cannot check file %q: %w", newPath, err)
+	}
+	content, err := os.ReadFile(oldPath)
+	if err != nil {
+		return fmt.Errorf("cannot read file %q: %w", oldPath, err)
+	}
+	err = os.MkdirAll(filepath.Dir(newPath), 0o700)
+	if err != nil {
+		return err
+	}
+	// copy instead of rename because both locations can be on different file systems
+	err = os.WriteFile(newPath, content, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write file %q: %w", newPath, err)
+	}
+	err = os.Remove(oldPath)
+	if err != nil {
+		return fmt.Errorf("cannot delete file %q: %w", oldPath, err)
+	}
+	return nil
 }
 
 func SanitizePath(dir string) string {
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package runstate
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processExists indicates whether a process with the given ID runs on this machine.
+func processExists(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
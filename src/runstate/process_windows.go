@@ -0,0 +1,16 @@
+//go:build windows
+
+package runstate
+
+import "os"
+
+// processExists indicates whether a process with the given ID runs on this machine.
+func processExists(pid int) bool {
+	// on Windows, finding a process fails if it doesn't exist
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
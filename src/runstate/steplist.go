@@ -62,6 +62,30 @@ func (stepList *StepList) PrependList(otherList StepList) {
 	stepList.List = append(otherList.List, stepList.List...)
 }
 
+// StepTypes provides the type names of the steps in this StepList.
+func (stepList *StepList) StepTypes() []string {
+	result := make([]string, len(stepList.List))
+	for s, step := range stepList.List {
+		if unknownStep, isUnknown := step.(*steps.UnknownStep); isUnknown {
+			result[s] = unknownStep.Type
+		} else {
+			result[s] = typeName(step)
+		}
+	}
+	return result
+}
+
+// withoutUnknownSteps provides a copy of this StepList without the steps that this version of Git Town cannot read.
+func (stepList *StepList) withoutUnknownSteps() StepList {
+	result := StepList{}
+	for _, step := range stepList.List {
+		if _, isUnknown := step.(*steps.UnknownStep); !isUnknown {
+			result.Append(step)
+		}
+	}
+	return result
+}
+
 // WrapOptions represents the options given to Wrap.
 type WrapOptions struct {
 	RunInGitRoot     bool
@@ -0,0 +1,97 @@
+// Package shipmessage renders the commit messages of shipped branches
+// from the ship message template in the Git Town configuration.
+package shipmessage
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/git-town/git-town/v7/src/stringslice"
+)
+
+// Data contains the information that ship message templates can refer to.
+type Data struct {
+	Branch         string   // name of the shipped branch
+	CoAuthors      []string // the authors of the branch other than the author of the squash commit
+	CommitSubjects []string // subjects of the commits on the branch, oldest first
+	ProposalBody   string   // body of the proposal for the branch, empty if there is none
+	ProposalNumber int      // number of the proposal for the branch, 0 if there is none
+	ProposalTitle  string   // title of the proposal for the branch, empty if there is none
+}
+
+// Placeholders provides the names of the placeholders that ship message templates can contain.
+func Placeholders() []string {
+	return []string{"branch", "co-authors", "commits", "issues", "proposal-body", "proposal-number", "proposal-title"}
+}
+
+// CoAuthorTrailers provides the "Co-authored-by" trailers for the given people, one per line.
+func CoAuthorTrailers(coAuthors []string) string {
+	trailers := make([]string, len(coAuthors))
+	for c, coAuthor := range coAuthors {
+		trailers[c] = "Co-authored-by: " + coAuthor
+	}
+	return strings.Join(trailers, "\n")
+}
+
+// IssueKeys provides the keys of the issue tracker tickets mentioned in the given branch name,
+// for example "ABC-123" for the branch "feature/ABC-123-login".
+func IssueKeys(branch string) []string {
+	return issueKeyRegex.FindAllString(branch, -1)
+}
+
+// Render provides the commit message that the given template describes for the given data.
+func Render(template string, data Data) (string, error) {
+	err := Validate(template)
+	if err != nil {
+		return "", err
+	}
+	values := map[string]string{
+		"branch":          data.Branch,
+		"co-authors":      CoAuthorTrailers(data.CoAuthors),
+		"commits":         commitList(data.CommitSubjects),
+		"issues":          strings.Join(IssueKeys(data.Branch), ", "),
+		"proposal-body":   data.ProposalBody,
+		"proposal-number": "",
+		"proposal-title":  data.ProposalTitle,
+	}
+	if data.ProposalNumber > 0 {
+		values["proposal-number"] = strconv.Itoa(data.ProposalNumber)
+	}
+	result := placeholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return values[placeholderName(placeholder)]
+	})
+	return strings.TrimSpace(result), nil
+}
+
+// Validate verifies that the given template contains only known placeholders.
+func Validate(template string) error {
+	for _, placeholder := range placeholderRegex.FindAllString(template, -1) {
+		name := placeholderName(placeholder)
+		if !stringslice.Contains(Placeholders(), name) {
+			return fmt.Errorf("unknown placeholder %q in the ship message template, the known placeholders are: %s", placeholder, strings.Join(Placeholders(), ", "))
+		}
+	}
+	return nil
+}
+
+// Helpers
+
+// issueKeyRegex matches issue keys like "ABC-123".
+var issueKeyRegex = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-[0-9]+\b`)
+
+// placeholderRegex matches placeholders like "{{branch}}".
+var placeholderRegex = regexp.MustCompile(`{{[^{}]*}}`)
+
+func commitList(subjects []string) string {
+	lines := make([]string, len(subjects))
+	for s, subject := range subjects {
+		lines[s] = "- " + subject
+	}
+	return strings.Join(lines, "\n")
+}
+
+func placeholderName(placeholder string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(placeholder, "{{"), "}}"))
+}
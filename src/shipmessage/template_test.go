@@ -0,0 +1,79 @@
+package shipmessage_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/shipmessage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoAuthorTrailers(t *testing.T) {
+	t.Parallel()
+	have := shipmessage.CoAuthorTrailers([]string{"one <one@example.com>", "two <two@example.com>"})
+	want := "Co-authored-by: one <one@example.com>\nCo-authored-by: two <two@example.com>"
+	assert.Equal(t, want, have)
+}
+
+func TestIssueKeys(t *testing.T) {
+	t.Parallel()
+	tests := map[string][]string{
+		"feature/ABC-123-login":  {"ABC-123"},
+		"ABC-123-and-DEF-45":     {"ABC-123", "DEF-45"},
+		"PROJ2-7":                {"PROJ2-7"},
+		"fix-login":              nil,
+		"abc-123-lowercase-keys": nil,
+		"version-2":              nil,
+	}
+	for give, want := range tests {
+		have := shipmessage.IssueKeys(give)
+		assert.Equal(t, want, have, give)
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	data := shipmessage.Data{
+		Branch:         "ABC-123-login",
+		CoAuthors:      []string{"coworker <coworker@example.com>"},
+		CommitSubjects: []string{"add login form", "validate password"},
+		ProposalBody:   "Lets people log in.",
+		ProposalNumber: 12,
+		ProposalTitle:  "Login",
+	}
+
+	t.Run("all placeholders", func(t *testing.T) {
+		t.Parallel()
+		template := "{{proposal-title}} (#{{proposal-number}})\n\n{{proposal-body}}\n\n{{commits}}\n\nbranch: {{branch}}\nissues: {{issues}}\n\n{{co-authors}}"
+		have, err := shipmessage.Render(template, data)
+		assert.Nil(t, err)
+		want := "Login (#12)\n\nLets people log in.\n\n- add login form\n- validate password\n\nbranch: ABC-123-login\nissues: ABC-123\n\nCo-authored-by: coworker <coworker@example.com>"
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("placeholders with spaces", func(t *testing.T) {
+		t.Parallel()
+		have, err := shipmessage.Render("{{ issues }}: {{ branch }}", data)
+		assert.Nil(t, err)
+		assert.Equal(t, "ABC-123: ABC-123-login", have)
+	})
+
+	t.Run("no proposal", func(t *testing.T) {
+		t.Parallel()
+		have, err := shipmessage.Render("{{branch}}\n\n{{proposal-title}}{{proposal-number}}", shipmessage.Data{Branch: "login"}) //nolint:exhaustruct
+		assert.Nil(t, err)
+		assert.Equal(t, "login", have)
+	})
+
+	t.Run("unknown placeholder", func(t *testing.T) {
+		t.Parallel()
+		_, err := shipmessage.Render("{{zonk}}", data)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, shipmessage.Validate("{{branch}}: {{commits}}"))
+	assert.Nil(t, shipmessage.Validate("no placeholders"))
+	assert.Error(t, shipmessage.Validate("{{branch}} {{title}}"))
+}
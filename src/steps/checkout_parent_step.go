@@ -0,0 +1,8 @@
+package steps
+
+// CheckoutParentStep checks out the parent branch that the branch currently being worked on gets merged into.
+// Unlike CheckoutStep, it doesn't start the steps for another branch,
+// so skipping the current branch also skips the steps after this one.
+type CheckoutParentStep struct {
+	CheckoutStep
+}
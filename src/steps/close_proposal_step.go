@@ -0,0 +1,19 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// CloseProposalStep closes the proposal with the given number without merging it.
+type CloseProposalStep struct {
+	EmptyStep
+	ProposalNumber int
+}
+
+func (step *CloseProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	if connector == nil {
+		return hosting.UnsupportedServiceError()
+	}
+	return connector.CloseProposal(step.ProposalNumber)
+}
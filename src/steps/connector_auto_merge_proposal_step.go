@@ -0,0 +1,92 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/shipmessage"
+)
+
+// ConnectorAutoMergeProposalStep makes the code hosting service merge the proposal for the branch with the given name
+// using the given ship strategy once all its requirements are met.
+// It marks the branch so that syncing removes it once the proposal is merged.
+type ConnectorAutoMergeProposalStep struct {
+	EmptyStep
+	AuthorMode             config.SquashAuthorMode
+	Branch                 string
+	CommitMessage          string
+	DefaultProposalMessage string
+	mergeError             error
+	MessageTemplate        string
+	ProposalBody           string
+	ProposalNumber         int
+	ProposalTitle          string
+	Strategy               config.ShipStrategy
+}
+
+func (step *ConnectorAutoMergeProposalStep) CreateAutomaticAbortError() error {
+	return step.mergeError
+}
+
+func (step *ConnectorAutoMergeProposalStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	return &ConnectorCancelAutoMergeStep{Branch: step.Branch, ProposalNumber: step.ProposalNumber}, nil
+}
+
+func (step *ConnectorAutoMergeProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	commitMessage, err := step.commitMessage(repo)
+	if err != nil {
+		return err
+	}
+	step.mergeError = connector.AutoMergeProposal(step.ProposalNumber, step.Strategy, commitMessage)
+	if step.mergeError != nil {
+		return step.mergeError
+	}
+	return repo.Config.SetAutoMergeProposal(step.Branch, step.ProposalNumber)
+}
+
+// ShouldAutomaticallyAbortOnError returns whether this step should cause the command to
+// automatically abort if it errors.
+func (step *ConnectorAutoMergeProposalStep) ShouldAutomaticallyAbortOnError() bool {
+	return true
+}
+
+// commitMessage provides the message for the commit that the code hosting service creates when merging the proposal.
+// Since nobody is around to enter it when the merge happens,
+// it uses the given commit message, the message template, or the default proposal message.
+func (step *ConnectorAutoMergeProposalStep) commitMessage(repo *git.ProdRepo) (string, error) {
+	if !step.Strategy.CreatesCommit() {
+		return "", nil
+	}
+	isSquash := step.Strategy == config.ShipStrategySquash
+	otherAuthors := []string{}
+	var err error
+	if isSquash && (step.AuthorMode.AddsCoAuthors() || step.MessageTemplate != "") {
+		otherAuthors, err = proposalCoAuthors(step.Branch, step.AuthorMode, repo)
+		if err != nil {
+			return "", fmt.Errorf("cannot determine the co-authors of the squash commit: %w", err)
+		}
+	}
+	result := step.CommitMessage
+	if result == "" && isSquash && step.MessageTemplate != "" {
+		result, err = renderShipMessage(step.MessageTemplate, shipmessage.Data{
+			Branch:         step.Branch,
+			CoAuthors:      otherAuthors,
+			CommitSubjects: []string{},
+			ProposalBody:   step.ProposalBody,
+			ProposalNumber: step.ProposalNumber,
+			ProposalTitle:  step.ProposalTitle,
+		}, repo)
+		if err != nil {
+			return "", err
+		}
+	}
+	if result == "" {
+		result = step.DefaultProposalMessage
+	}
+	if isSquash && step.AuthorMode.AddsCoAuthors() {
+		result = addCoAuthorTrailers(result, otherAuthors)
+	}
+	return result, nil
+}
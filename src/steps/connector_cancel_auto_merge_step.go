@@ -0,0 +1,25 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// ConnectorCancelAutoMergeStep stops the code hosting service from merging the proposal with the given number automatically
+// and removes the mark that the branch with the given name waits for this merge.
+type ConnectorCancelAutoMergeStep struct {
+	EmptyStep
+	Branch         string
+	ProposalNumber int
+}
+
+func (step *ConnectorCancelAutoMergeStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	err := connector.CancelAutoMerge(step.ProposalNumber)
+	if err != nil {
+		return err
+	}
+	if repo.Config.AutoMergeProposal(step.Branch) == 0 {
+		return nil
+	}
+	return repo.Config.RemoveAutoMergeProposal(step.Branch)
+}
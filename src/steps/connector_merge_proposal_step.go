@@ -2,21 +2,32 @@ package steps
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/shipmessage"
 )
 
-// ConnectorMergeProposalStep squash merges the branch with the given name into the current branch.
+// ConnectorMergeProposalStep merges the proposal for the branch with the given name
+// via the API of the code hosting service, using the given ship strategy.
 type ConnectorMergeProposalStep struct {
 	EmptyStep
+	AuthorMode                config.SquashAuthorMode
 	Branch                    string
 	CommitMessage             string
 	DefaultProposalMessage    string
 	enteredEmptyCommitMessage bool
 	mergeError                error
 	mergeSha                  string
+	MessageTemplate           string
+	previousSha               string
+	ProposalBody              string
 	ProposalNumber            int
+	ProposalTarget            string
+	ProposalTitle             string
+	Strategy                  config.ShipStrategy
 }
 
 func (step *ConnectorMergeProposalStep) CreateAbortStep() Step {
@@ -27,7 +38,16 @@ func (step *ConnectorMergeProposalStep) CreateAbortStep() Step {
 }
 
 func (step *ConnectorMergeProposalStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
-	return &RevertCommitStep{Sha: step.mergeSha}, nil
+	return &RevertProposalMergeStep{
+		Branch:         step.Branch,
+		MergeSha:       step.mergeSha,
+		PreviousSha:    step.previousSha,
+		ProposalBody:   step.ProposalBody,
+		ProposalNumber: step.ProposalNumber,
+		ProposalTarget: step.ProposalTarget,
+		ProposalTitle:  step.ProposalTitle,
+		Strategy:       step.strategy(),
+	}, nil
 }
 
 func (step *ConnectorMergeProposalStep) CreateAutomaticAbortError() error {
@@ -38,13 +58,39 @@ func (step *ConnectorMergeProposalStep) CreateAutomaticAbortError() error {
 }
 
 func (step *ConnectorMergeProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	var err error
+	step.previousSha, err = repo.Silent.CurrentSha()
+	if err != nil {
+		return err
+	}
 	commitMessage := step.CommitMessage
+	isSquash := step.strategy() == config.ShipStrategySquash
+	otherAuthors := []string{}
+	if isSquash && (step.AuthorMode.AddsCoAuthors() || step.MessageTemplate != "") {
+		otherAuthors, err = step.otherAuthors(repo)
+		if err != nil {
+			return fmt.Errorf("cannot determine the co-authors of the squash commit: %w", err)
+		}
+	}
+	if commitMessage == "" && isSquash && step.MessageTemplate != "" {
+		commitMessage, err = renderShipMessage(step.MessageTemplate, shipmessage.Data{
+			Branch:         step.Branch,
+			CoAuthors:      otherAuthors,
+			CommitSubjects: []string{},
+			ProposalBody:   step.ProposalBody,
+			ProposalNumber: step.ProposalNumber,
+			ProposalTitle:  step.ProposalTitle,
+		}, repo)
+		if err != nil {
+			return err
+		}
+	}
 	//nolint:nestif
-	if commitMessage == "" {
+	if commitMessage == "" && step.strategy().CreatesCommit() {
 		// Allow the user to enter the commit message as if shipping without a connector
 		// then revert the commit since merging via the connector will perform the actual squash merge.
 		step.enteredEmptyCommitMessage = true
-		err := repo.Logging.SquashMerge(step.Branch)
+		err = repo.Logging.SquashMerge(step.Branch)
 		if err != nil {
 			return err
 		}
@@ -66,7 +112,13 @@ func (step *ConnectorMergeProposalStep) Run(repo *git.ProdRepo, connector hostin
 		}
 		step.enteredEmptyCommitMessage = false
 	}
-	step.mergeSha, step.mergeError = connector.SquashMergeProposal(step.ProposalNumber, commitMessage)
+	if isSquash && step.AuthorMode.AddsCoAuthors() {
+		commitMessage = addCoAuthorTrailers(commitMessage, otherAuthors)
+	}
+	step.mergeSha, step.mergeError = connector.MergeProposal(step.ProposalNumber, step.strategy(), commitMessage)
+	if step.mergeError != nil {
+		step.mergeError = explainMergeError(step.mergeError, step.ProposalNumber, connector)
+	}
 	return step.mergeError
 }
 
@@ -75,3 +127,57 @@ func (step *ConnectorMergeProposalStep) Run(repo *git.ProdRepo, connector hostin
 func (step *ConnectorMergeProposalStep) ShouldAutomaticallyAbortOnError() bool {
 	return true
 }
+
+// otherAuthors provides the authors of the shipped branch other than the author of the squash commit.
+func (step *ConnectorMergeProposalStep) otherAuthors(repo *git.ProdRepo) ([]string, error) {
+	return proposalCoAuthors(step.Branch, step.AuthorMode, repo)
+}
+
+// proposalCoAuthors provides the authors of the given branch other than the author
+// of the squash commit that the code hosting service creates when merging its proposal with the given author mode.
+func proposalCoAuthors(branch string, mode config.SquashAuthorMode, repo *git.ProdRepo) ([]string, error) {
+	if !mode.AddsCoAuthors() {
+		// the code hosting service makes the author of the proposal, usually the branch owner, the author of the squash commit
+		mode = config.SquashAuthorModeBranchOwner
+	}
+	_, otherAuthors, err := squashCommitAuthors(branch, mode, repo)
+	return otherAuthors, err
+}
+
+// addCoAuthorTrailers provides the given commit message with "Co-authored-by" trailers
+// for the given people that it doesn't credit yet.
+func addCoAuthorTrailers(message string, coAuthors []string) string {
+	missing := []string{}
+	for _, coAuthor := range coAuthors {
+		if !strings.Contains(message, shipmessage.CoAuthorTrailers([]string{coAuthor})) {
+			missing = append(missing, coAuthor)
+		}
+	}
+	if len(missing) == 0 {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + shipmessage.CoAuthorTrailers(missing)
+}
+
+// explainMergeError adds the checks and reviews that the proposal with the given number is missing to the given error
+// that the code hosting service returned when merging it.
+func explainMergeError(mergeError error, number int, connector hosting.Connector) error {
+	checks, err := connector.ProposalChecks(number)
+	if err != nil {
+		return mergeError
+	}
+	problems := checks.Problems()
+	if len(problems) == 0 {
+		return mergeError
+	}
+	return fmt.Errorf("%w\nthe proposal isn't ready to ship: %s", mergeError, strings.Join(problems, ", "))
+}
+
+// strategy provides the ship strategy to merge the proposal with.
+func (step *ConnectorMergeProposalStep) strategy() config.ShipStrategy {
+	if step.Strategy == "" {
+		// run states stored by earlier versions of Git Town don't contain a strategy
+		return config.ShipStrategySquash
+	}
+	return step.Strategy
+}
@@ -0,0 +1,71 @@
+package steps_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/steps"
+	"github.com/git-town/git-town/v7/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// mergingConnector is a hosting.Connector that merges proposals into the commit with the given SHA.
+type mergingConnector struct {
+	hosting.Connector
+	mergeSha string
+}
+
+func (c mergingConnector) MergeProposal(number int, strategy config.ShipStrategy, message string) (string, error) {
+	return c.mergeSha, nil
+}
+
+func TestConnectorMergeProposalStep(t *testing.T) {
+	t.Parallel()
+	t.Run("CreateUndoStep", func(t *testing.T) {
+		t.Parallel()
+		tests := map[config.ShipStrategy]config.ShipStrategy{
+			config.ShipStrategyMerge:  config.ShipStrategyMerge,
+			config.ShipStrategyRebase: config.ShipStrategyRebase,
+			config.ShipStrategySquash: config.ShipStrategySquash,
+			// run states stored by earlier versions of Git Town don't contain a strategy
+			"": config.ShipStrategySquash,
+		}
+		for give, want := range tests {
+			give, want := give, want
+			t.Run(string(give), func(t *testing.T) {
+				t.Parallel()
+				repo := test.CreateTestGitTownRepo(t)
+				err := repo.CheckoutBranch("main")
+				assert.NoError(t, err)
+				previousSha, err := repo.CurrentSha()
+				assert.NoError(t, err)
+				prodRepo := git.ProdRepo{Silent: repo.Runner} //nolint:exhaustruct
+				step := steps.ConnectorMergeProposalStep{     //nolint:exhaustruct
+					Branch:         "feature",
+					CommitMessage:  "feature done",
+					ProposalBody:   "my body",
+					ProposalNumber: 1,
+					ProposalTarget: "main",
+					ProposalTitle:  "my title",
+					Strategy:       give,
+				}
+				err = step.Run(&prodRepo, mergingConnector{mergeSha: "123456"}) //nolint:exhaustruct
+				assert.NoError(t, err)
+				have, err := step.CreateUndoStep(&prodRepo)
+				assert.NoError(t, err)
+				assert.Equal(t, &steps.RevertProposalMergeStep{ //nolint:exhaustruct
+					Branch:         "feature",
+					MergeSha:       "123456",
+					PreviousSha:    previousSha,
+					ProposalBody:   "my body",
+					ProposalNumber: 1,
+					ProposalTarget: "main",
+					ProposalTitle:  "my title",
+					Strategy:       want,
+				}, have)
+			})
+		}
+	})
+}
@@ -2,18 +2,57 @@ package steps
 
 import (
 	"github.com/git-town/git-town/v7/src/browser"
+	"github.com/git-town/git-town/v7/src/cli"
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
 )
 
 // CreateProposalStep creates a new pull request for the current branch.
+// By default it opens the new proposal page of the code hosting service in the browser.
+// With UseAPI it creates the proposal directly via the API of the code hosting service.
 type CreateProposalStep struct {
 	EmptyStep
-	Branch string
+	Body           string
+	Branch         string
+	Draft          bool
+	Title          string
+	UseAPI         bool
+	proposalNumber int
+}
+
+func (step *CreateProposalStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	if step.proposalNumber == 0 {
+		return &EmptyStep{}, nil
+	}
+	return &CloseProposalStep{ProposalNumber: step.proposalNumber}, nil
 }
 
 func (step *CreateProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
 	parentBranch := repo.Config.ParentBranch(step.Branch)
+	if step.UseAPI {
+		existing, err := connector.FindProposal(step.Branch, parentBranch)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			cli.Printf("branch %q already has a proposal: %s\n", step.Branch, existing.URL)
+			if step.Title != "" || step.Body != "" {
+				cli.Println("The given title and body only apply to new proposals, please update the existing proposal on your code hosting service.")
+			}
+			return nil
+		}
+		title := step.Title
+		if title == "" {
+			title = step.Branch
+		}
+		proposal, err := connector.CreateProposal(step.Branch, parentBranch, title, step.Body, step.Draft)
+		if err != nil {
+			return err
+		}
+		step.proposalNumber = proposal.Number
+		cli.Println(proposal.URL)
+		return nil
+	}
 	prURL, err := connector.NewProposalURL(step.Branch, parentBranch)
 	if err != nil {
 		return err
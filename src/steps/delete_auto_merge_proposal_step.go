@@ -0,0 +1,28 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// DeleteAutoMergeProposalStep removes the mark that the branch with the given name waits for its proposal to get merged automatically.
+type DeleteAutoMergeProposalStep struct {
+	EmptyStep
+	Branch           string
+	previousProposal int
+}
+
+func (step *DeleteAutoMergeProposalStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	if step.previousProposal == 0 {
+		return &EmptyStep{}, nil
+	}
+	return &SetAutoMergeProposalStep{Branch: step.Branch, ProposalNumber: step.previousProposal}, nil
+}
+
+func (step *DeleteAutoMergeProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	step.previousProposal = repo.Config.AutoMergeProposal(step.Branch)
+	if step.previousProposal == 0 {
+		return nil
+	}
+	return repo.Config.RemoveAutoMergeProposal(step.Branch)
+}
@@ -0,0 +1,46 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// FastForwardStep fast-forwards the current branch to the branch with the given name.
+// Pushed indicates that the command pushes the current branch afterwards,
+// so that undoing must revert the fast-forwarded commits instead of removing them.
+type FastForwardStep struct {
+	EmptyStep
+	Branch      string
+	Pushed      bool
+	previousSha string
+}
+
+func (step *FastForwardStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	if !step.Pushed {
+		return &ResetToShaStep{Hard: true, Sha: step.previousSha}, nil
+	}
+	currentSHA, err := repo.Silent.CurrentSha()
+	if err != nil {
+		return nil, err
+	}
+	return &RevertCommitsStep{PreviousSha: step.previousSha, Sha: currentSHA}, nil
+}
+
+func (step *FastForwardStep) CreateAutomaticAbortError() error {
+	return fmt.Errorf("aborted because fast-forward exited with error")
+}
+
+func (step *FastForwardStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	var err error
+	step.previousSha, err = repo.Silent.CurrentSha()
+	if err != nil {
+		return err
+	}
+	return repo.Logging.FastForward(step.Branch)
+}
+
+func (step *FastForwardStep) ShouldAutomaticallyAbortOnError() bool {
+	return true
+}
@@ -0,0 +1,42 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/dialog"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// MergeNoFastForwardStep merges the branch with the given name into the current branch
+// with a merge commit.
+type MergeNoFastForwardStep struct {
+	EmptyStep
+	Branch        string
+	CommitMessage string
+}
+
+func (step *MergeNoFastForwardStep) CreateAbortStep() Step {
+	return &DiscardOpenChangesStep{}
+}
+
+func (step *MergeNoFastForwardStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	currentSHA, err := repo.Silent.CurrentSha()
+	if err != nil {
+		return nil, err
+	}
+	return &RevertMergeCommitStep{Sha: currentSHA}, nil
+}
+
+func (step *MergeNoFastForwardStep) CreateAutomaticAbortError() error {
+	return fmt.Errorf("aborted because merge exited with error")
+}
+
+func (step *MergeNoFastForwardStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	// non-interactive runs cannot enter the commit message in the editor
+	return repo.Logging.MergeBranchNoFastForward(step.Branch, step.CommitMessage, dialog.IsInteractive())
+}
+
+func (step *MergeNoFastForwardStep) ShouldAutomaticallyAbortOnError() bool {
+	return true
+}
@@ -13,6 +13,10 @@ type PreserveCheckoutHistoryStep struct {
 }
 
 func (step *PreserveCheckoutHistoryStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	if repo.DryRun.IsActive() {
+		// the checkout history doesn't change in dry-run mode
+		return nil
+	}
 	expectedPreviouslyCheckedOutBranch, err := repo.Silent.ExpectedPreviouslyCheckedOutBranch(step.InitialPreviouslyCheckedOutBranch, step.InitialBranch)
 	if err != nil {
 		return err
@@ -21,6 +21,14 @@ func (step *PushBranchStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
 	if step.Undoable {
 		return &PushBranchAfterCurrentBranchSteps{}, nil
 	}
+	currentBranch, err := repo.Silent.CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if step.Branch != currentBranch {
+		// pushing another branch doesn't prevent undoing the changes to the current branch
+		return &EmptyStep{}, nil
+	}
 	return &SkipCurrentBranchSteps{}, nil
 }
 
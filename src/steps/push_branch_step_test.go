@@ -0,0 +1,50 @@
+package steps_test
+
+import (
+	"testing"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/steps"
+	"github.com/git-town/git-town/v7/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushBranchStep(t *testing.T) {
+	t.Parallel()
+	t.Run("CreateUndoStep", func(t *testing.T) {
+		t.Parallel()
+		t.Run("undoable push", func(t *testing.T) {
+			t.Parallel()
+			repo := test.CreateTestGitTownRepo(t)
+			prodRepo := git.ProdRepo{Silent: repo.Runner}                //nolint:exhaustruct
+			step := steps.PushBranchStep{Branch: "main", Undoable: true} //nolint:exhaustruct
+			have, err := step.CreateUndoStep(&prodRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, &steps.PushBranchAfterCurrentBranchSteps{}, have)
+		})
+
+		t.Run("push of the current branch", func(t *testing.T) {
+			t.Parallel()
+			repo := test.CreateTestGitTownRepo(t)
+			err := repo.CheckoutBranch("main")
+			assert.NoError(t, err)
+			prodRepo := git.ProdRepo{Silent: repo.Runner} //nolint:exhaustruct
+			step := steps.PushBranchStep{Branch: "main"}  //nolint:exhaustruct
+			have, err := step.CreateUndoStep(&prodRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, &steps.SkipCurrentBranchSteps{}, have)
+		})
+
+		t.Run("push of another branch", func(t *testing.T) {
+			t.Parallel()
+			repo := test.CreateTestGitTownRepo(t)
+			err := repo.CheckoutBranch("main")
+			assert.NoError(t, err)
+			prodRepo := git.ProdRepo{Silent: repo.Runner}   //nolint:exhaustruct
+			step := steps.PushBranchStep{Branch: "feature"} //nolint:exhaustruct
+			have, err := step.CreateUndoStep(&prodRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, &steps.EmptyStep{}, have)
+		})
+	})
+}
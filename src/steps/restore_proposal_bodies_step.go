@@ -0,0 +1,28 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// RestoreProposalBodiesStep sets the bodies of the proposals with the given numbers to the given values.
+type RestoreProposalBodiesStep struct {
+	EmptyStep
+	Bodies map[int]string
+}
+
+func (step *RestoreProposalBodiesStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	if len(step.Bodies) == 0 {
+		return nil
+	}
+	if connector == nil {
+		return hosting.UnsupportedServiceError()
+	}
+	for number, body := range step.Bodies {
+		err := connector.UpdateProposalBody(number, body)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
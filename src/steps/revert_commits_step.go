@@ -0,0 +1,21 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// RevertCommitsStep reverts the commits that the commit with the given sha
+// added on top of the commit with the given previous sha.
+type RevertCommitsStep struct {
+	EmptyStep
+	PreviousSha string
+	Sha         string
+}
+
+func (step *RevertCommitsStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	if step.PreviousSha == step.Sha {
+		return nil
+	}
+	return repo.Logging.RevertCommits(step.PreviousSha, step.Sha)
+}
@@ -0,0 +1,16 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// RevertMergeCommitStep reverts the merge commit with the given sha.
+type RevertMergeCommitStep struct {
+	EmptyStep
+	Sha string
+}
+
+func (step *RevertMergeCommitStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	return repo.Logging.RevertMergeCommit(step.Sha)
+}
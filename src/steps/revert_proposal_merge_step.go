@@ -0,0 +1,69 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/cli"
+	"github.com/git-town/git-town/v7/src/config"
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// RevertProposalMergeStep undoes merging a proposal via the API of the code hosting service.
+// It reverts the commits that the merge added to the target branch and proposes the branch again,
+// because code hosting services don't allow reopening merged proposals.
+type RevertProposalMergeStep struct {
+	EmptyStep
+	Branch         string
+	MergeSha       string
+	PreviousSha    string
+	ProposalBody   string
+	ProposalNumber int
+	ProposalTarget string
+	ProposalTitle  string
+	Strategy       config.ShipStrategy
+}
+
+func (step *RevertProposalMergeStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	err := step.revertMerge(repo)
+	if err != nil {
+		return err
+	}
+	if connector == nil {
+		cli.Printf("Proposal #%d stays merged on the code hosting service. To propose branch %q again, create a new proposal for it.\n", step.ProposalNumber, step.Branch)
+		return nil
+	}
+	cli.Printf("%s doesn't allow reopening merged proposals, proposal #%d stays merged.\n", connector.HostingServiceName(), step.ProposalNumber)
+	proposal, err := connector.CreateProposal(step.Branch, step.ProposalTarget, step.ProposalTitle, step.ProposalBody, false)
+	if err != nil {
+		cli.PrintError(fmt.Errorf("cannot propose branch %q again, please create a new proposal for it: %w", step.Branch, err))
+		return nil
+	}
+	cli.Printf("Created proposal #%d for branch %q instead: %s\n", proposal.Number, step.Branch, proposal.URL)
+	return nil
+}
+
+// revertMerge reverts the commits that merging the proposal added to the target branch.
+func (step *RevertProposalMergeStep) revertMerge(repo *git.ProdRepo) error {
+	// Undoing the ship of a branch in a fork resets the main branch to before it received the merge from the upstream repo.
+	// The upstream repo keeps the merge, so the main branch receives it again in order to revert it.
+	hasMerge, err := repo.Silent.IsAncestor(step.MergeSha)
+	if err != nil {
+		return err
+	}
+	if !hasMerge {
+		err = repo.Logging.FastForward(step.MergeSha)
+		if err != nil {
+			return err
+		}
+	}
+	switch step.Strategy {
+	case config.ShipStrategyMerge:
+		return repo.Logging.RevertMergeCommit(step.MergeSha)
+	case config.ShipStrategyRebase, config.ShipStrategyFastForward:
+		return repo.Logging.RevertCommits(step.PreviousSha, step.MergeSha)
+	case config.ShipStrategySquash:
+	}
+	// run states stored by earlier versions of Git Town don't contain a strategy, they always squash-merged
+	return repo.Logging.RevertCommit(step.MergeSha)
+}
@@ -0,0 +1,22 @@
+package steps
+
+import (
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// SetAutoMergeProposalStep marks the branch with the given name as waiting for the proposal with the given number
+// to get merged automatically.
+type SetAutoMergeProposalStep struct {
+	EmptyStep
+	Branch         string
+	ProposalNumber int
+}
+
+func (step *SetAutoMergeProposalStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	return &DeleteAutoMergeProposalStep{Branch: step.Branch}, nil
+}
+
+func (step *SetAutoMergeProposalStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	return repo.Config.SetAutoMergeProposal(step.Branch, step.ProposalNumber)
+}
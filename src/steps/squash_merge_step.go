@@ -3,16 +3,21 @@ package steps
 import (
 	"fmt"
 
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/src/dialog"
 	"github.com/git-town/git-town/v7/src/git"
 	"github.com/git-town/git-town/v7/src/hosting"
+	"github.com/git-town/git-town/v7/src/shipmessage"
+	"github.com/git-town/git-town/v7/src/stringslice"
 )
 
 // SquashMergeStep squash merges the branch with the given name into the current branch.
 type SquashMergeStep struct {
 	EmptyStep
-	Branch        string
-	CommitMessage string
+	AuthorMode      config.SquashAuthorMode
+	Branch          string
+	CommitMessage   string
+	MessageTemplate string
 }
 
 func (step *SquashMergeStep) CreateAbortStep() Step {
@@ -36,23 +41,94 @@ func (step *SquashMergeStep) Run(repo *git.ProdRepo, connector hosting.Connector
 	if err != nil {
 		return err
 	}
-	author, err := dialog.DetermineSquashCommitAuthor(step.Branch, repo)
+	author, otherAuthors, err := squashCommitAuthors(step.Branch, step.AuthorMode, repo)
 	if err != nil {
 		return fmt.Errorf("error getting squash commit author: %w", err)
 	}
-	repoAuthor, err := repo.Silent.Author()
-	if err != nil {
-		return fmt.Errorf("cannot determine repo author: %w", err)
-	}
 	if err = repo.Silent.CommentOutSquashCommitMessage(""); err != nil {
 		return fmt.Errorf("cannot comment out the squash commit message: %w", err)
 	}
-	if repoAuthor == author {
-		author = ""
+	if step.CommitMessage == "" && step.MessageTemplate != "" {
+		// local ships have no proposal
+		message, err := renderShipMessage(step.MessageTemplate, shipmessage.Data{
+			Branch:         step.Branch,
+			CoAuthors:      otherAuthors,
+			CommitSubjects: []string{},
+			ProposalBody:   "",
+			ProposalNumber: 0,
+			ProposalTitle:  "",
+		}, repo)
+		if err != nil {
+			return err
+		}
+		err = repo.Silent.PrefillSquashCommitMessage(message)
+		if err != nil {
+			return fmt.Errorf("cannot pre-fill the squash commit message: %w", err)
+		}
+	}
+	coAuthors := []string{}
+	if step.AuthorMode.AddsCoAuthors() {
+		coAuthors = otherAuthors
+	}
+	if step.CommitMessage != "" || len(coAuthors) == 0 {
+		return repo.Logging.Commit(step.CommitMessage, author, coAuthors)
+	}
+	// add the trailers only after the user has entered the commit message,
+	// so that entering an empty commit message still aborts the commit
+	err = repo.Logging.Commit("", author, []string{})
+	if err != nil {
+		return err
 	}
-	return repo.Logging.Commit(step.CommitMessage, author)
+	return repo.Logging.AddCoAuthors(coAuthors)
 }
 
 func (step *SquashMergeStep) ShouldAutomaticallyAbortOnError() bool {
 	return true
 }
+
+// renderShipMessage provides the commit message that the given ship message template describes
+// for the branch and proposal in the given data.
+// Adds the commits of the branch to the data.
+func renderShipMessage(template string, data shipmessage.Data, repo *git.ProdRepo) (string, error) {
+	var err error
+	data.CommitSubjects, err = repo.Silent.CommitSubjects(data.Branch)
+	if err != nil {
+		return "", err
+	}
+	return shipmessage.Render(template, data)
+}
+
+// squashCommitAuthors provides the author of the squash commit for the branch with the given name
+// in the given squash author mode, and the other authors of the branch.
+// The author is empty if it is the current user.
+func squashCommitAuthors(branch string, mode config.SquashAuthorMode, repo *git.ProdRepo) (string, []string, error) {
+	repoAuthor, err := repo.Silent.Author()
+	if err != nil {
+		return "", []string{}, fmt.Errorf("cannot determine repo author: %w", err)
+	}
+	canonicalRepoAuthor, err := repo.Silent.CanonicalAuthor(repoAuthor)
+	if err != nil {
+		return "", []string{}, err
+	}
+	var author string
+	switch mode {
+	case config.SquashAuthorModeChoose, "":
+		author, err = dialog.DetermineSquashCommitAuthor(branch, repo)
+	case config.SquashAuthorModeBranchOwner:
+		author, err = repo.Silent.BranchOwner(branch)
+	case config.SquashAuthorModeCurrentUser:
+		author = canonicalRepoAuthor
+	}
+	if err != nil {
+		return "", []string{}, err
+	}
+	branchAuthors, err := repo.Silent.BranchAuthors(branch)
+	if err != nil {
+		return "", []string{}, err
+	}
+	otherAuthors := stringslice.Remove(branchAuthors, author)
+	if author == repoAuthor || author == canonicalRepoAuthor {
+		author = ""
+	}
+	return author, otherAuthors, nil
+}
@@ -0,0 +1,26 @@
+package steps
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// UnknownStep is a step from a stored run state that this version of Git Town cannot read,
+// for example because the version of Git Town that stored it had a step that no longer exists.
+// It keeps the stored data so that storing the run state again doesn't lose it.
+type UnknownStep struct {
+	EmptyStep
+	Data json.RawMessage
+	Type string
+}
+
+func (step *UnknownStep) CreateContinueStep() Step {
+	return step
+}
+
+func (step *UnknownStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	return fmt.Errorf("this version of Git Town cannot run the step %q from the stored run state", step.Type)
+}
@@ -0,0 +1,52 @@
+package steps
+
+import (
+	"fmt"
+
+	"github.com/git-town/git-town/v7/src/git"
+	"github.com/git-town/git-town/v7/src/hosting"
+)
+
+// UpdateProposalStackStep writes a section listing all proposals of the given stack of branches
+// into the body of each of these proposals.
+type UpdateProposalStackStep struct {
+	EmptyStep
+	Branches       []string
+	previousBodies map[int]string
+}
+
+// CreateAbortStep restores the bodies of the proposals that this step has updated before it failed.
+func (step *UpdateProposalStackStep) CreateAbortStep() Step {
+	return &RestoreProposalBodiesStep{Bodies: step.previousBodies}
+}
+
+func (step *UpdateProposalStackStep) CreateUndoStep(repo *git.ProdRepo) (Step, error) {
+	return &RestoreProposalBodiesStep{Bodies: step.previousBodies}, nil
+}
+
+func (step *UpdateProposalStackStep) Run(repo *git.ProdRepo, connector hosting.Connector) error {
+	step.previousBodies = map[int]string{}
+	stack := make([]hosting.Proposal, 0, len(step.Branches))
+	for _, branch := range step.Branches {
+		proposal, err := connector.FindProposal(branch, repo.Config.ParentBranch(branch))
+		if err != nil {
+			return err
+		}
+		if proposal == nil {
+			return fmt.Errorf("cannot find the proposal for branch %q", branch)
+		}
+		stack = append(stack, *proposal)
+	}
+	for p, proposal := range stack {
+		body := hosting.UpdateStackSection(proposal.Body, hosting.StackSection(stack, p))
+		if body == proposal.Body {
+			continue
+		}
+		err := connector.UpdateProposalBody(proposal.Number, body)
+		if err != nil {
+			return err
+		}
+		step.previousBodies[proposal.Number] = proposal.Body
+	}
+	return nil
+}
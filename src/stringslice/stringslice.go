@@ -32,7 +32,7 @@ func Hoist(list []string, element string) []string {
 // Remove returns a new string slice which is the given string slice
 // with the given string removed.
 func Remove(list []string, value string) []string {
-	result := make([]string, 0, len(list)-1)
+	result := make([]string, 0, len(list))
 	for _, element := range list {
 		if element != value {
 			result = append(result, element)
@@ -0,0 +1,263 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FakeGitHub simulates the GitHub API for the "git-town/git-town" repository in Cucumber scenarios.
+// Merging pull requests squash-merges their branches in the given repo.
+type FakeGitHub struct {
+	fork         *Repo  // optional fork of the simulated repository that contains the branches of pull requests
+	forkOwner    string // the organization that owns the fork
+	mutex        sync.Mutex
+	pullRequests []*fakePullRequest
+	repo         *Repo
+	server       *httptest.Server
+}
+
+// fakePullRequest is a pull request in the FakeGitHub API, serialized like the GitHub API does.
+type fakePullRequest struct {
+	Number int           `json:"number"`
+	Title  string        `json:"title"`
+	Body   string        `json:"body"`
+	Head   fakeBranchRef `json:"head"`
+	Base   fakeBranchRef `json:"base"`
+	State  string        `json:"state"`
+	URL    string        `json:"html_url"`
+	owner  string        // the organization that owns the repo containing the head branch
+}
+
+type fakeBranchRef struct {
+	Ref string `json:"ref"`
+}
+
+// fakeGitHubPullsPath matches the API paths of the pull requests of the simulated repository.
+var fakeGitHubPullsPath = regexp.MustCompile(`^/api/v3/repos/git-town/git-town/pulls(?:/(\d+)(/merge)?)?$`) //nolint:gochecknoglobals
+
+// fakeGitHubOwner is the organization that owns the repository that FakeGitHub simulates.
+const fakeGitHubOwner = "git-town"
+
+// NewFakeGitHub starts a FakeGitHub API server that merges pull requests in the given repo.
+// The caller must close it.
+func NewFakeGitHub(repo *Repo) *FakeGitHub {
+	result := FakeGitHub{repo: repo} //nolint:exhaustruct
+	result.server = httptest.NewServer(http.HandlerFunc(result.handle))
+	return &result
+}
+
+// PullRequestBody provides the body of the pull request with the given number.
+func (gh *FakeGitHub) PullRequestBody(number int) (string, error) {
+	gh.mutex.Lock()
+	defer gh.mutex.Unlock()
+	if number < 1 || number > len(gh.pullRequests) {
+		return "", fmt.Errorf("FakeGitHub has no pull request #%d", number)
+	}
+	return gh.pullRequests[number-1].Body, nil
+}
+
+// PullRequestTable provides a table of the pull requests in this FakeGitHub server.
+func (gh *FakeGitHub) PullRequestTable() DataTable {
+	gh.mutex.Lock()
+	defer gh.mutex.Unlock()
+	result := DataTable{}
+	result.AddRow("NUMBER", "BRANCH", "TARGET", "TITLE")
+	for _, pullRequest := range gh.pullRequests {
+		result.AddRow(strconv.Itoa(pullRequest.Number), pullRequest.Head.Ref, pullRequest.Base.Ref, pullRequest.Title)
+	}
+	return result
+}
+
+// AddPullRequest adds an open pull request for the given branch into the given target branch.
+// The branch lives in the fork if there is one.
+func (gh *FakeGitHub) AddPullRequest(branch, target, title string) {
+	gh.mutex.Lock()
+	defer gh.mutex.Unlock()
+	owner := fakeGitHubOwner
+	if gh.fork != nil {
+		owner = gh.forkOwner
+	}
+	gh.addPullRequest(owner, branch, target, title, "")
+}
+
+// SetFork makes the given repo the fork of the simulated repository that the given organization owns.
+func (gh *FakeGitHub) SetFork(owner string, fork *Repo) {
+	gh.mutex.Lock()
+	defer gh.mutex.Unlock()
+	gh.fork = fork
+	gh.forkOwner = owner
+}
+
+// APIURL provides the URL of the REST API of this FakeGitHub server.
+func (gh *FakeGitHub) APIURL() string {
+	return gh.server.URL + "/api/v3/"
+}
+
+// Close stops this FakeGitHub server.
+func (gh *FakeGitHub) Close() {
+	gh.server.Close()
+}
+
+func (gh *FakeGitHub) addPullRequest(owner, branch, target, title, body string) *fakePullRequest {
+	number := len(gh.pullRequests) + 1
+	pullRequest := fakePullRequest{
+		Number: number,
+		Title:  title,
+		Body:   body,
+		Head:   fakeBranchRef{Ref: branch},
+		Base:   fakeBranchRef{Ref: target},
+		State:  "open",
+		URL:    fmt.Sprintf("https://github.com/git-town/git-town/pull/%d", number),
+		owner:  owner,
+	}
+	gh.pullRequests = append(gh.pullRequests, &pullRequest)
+	return &pullRequest
+}
+
+func (gh *FakeGitHub) handle(w http.ResponseWriter, r *http.Request) {
+	gh.mutex.Lock()
+	defer gh.mutex.Unlock()
+	matches := fakeGitHubPullsPath.FindStringSubmatch(r.URL.Path)
+	switch {
+	case matches == nil:
+		http.Error(w, fmt.Sprintf("FakeGitHub doesn't support %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+	case matches[1] == "" && r.Method == http.MethodGet:
+		gh.listPullRequests(w, r)
+	case matches[1] == "" && r.Method == http.MethodPost:
+		gh.createPullRequest(w, r)
+	case matches[2] == "" && r.Method == http.MethodPatch:
+		number, _ := strconv.Atoi(matches[1])
+		gh.editPullRequest(w, r, number)
+	case matches[2] != "" && r.Method == http.MethodPut:
+		number, _ := strconv.Atoi(matches[1])
+		gh.mergePullRequest(w, r, number)
+	default:
+		http.Error(w, fmt.Sprintf("FakeGitHub doesn't support %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+	}
+}
+
+func (gh *FakeGitHub) createPullRequest(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Title string `json:"title"`
+		Head  string `json:"head"`
+		Base  string `json:"base"`
+		Body  string `json:"body"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	owner, branch := parseFakeGitHubHead(request.Head)
+	pullRequest := gh.addPullRequest(owner, branch, request.Base, request.Title, request.Body)
+	writeFakeGitHubJSON(w, http.StatusCreated, pullRequest)
+}
+
+func (gh *FakeGitHub) editPullRequest(w http.ResponseWriter, r *http.Request, number int) {
+	if number < 1 || number > len(gh.pullRequests) {
+		http.Error(w, fmt.Sprintf("pull request #%d doesn't exist", number), http.StatusNotFound)
+		return
+	}
+	pullRequest := gh.pullRequests[number-1]
+	var request struct {
+		Base *string `json:"base"`
+		Body *string `json:"body"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.Base != nil {
+		pullRequest.Base.Ref = *request.Base
+	}
+	if request.Body != nil {
+		pullRequest.Body = *request.Body
+	}
+	writeFakeGitHubJSON(w, http.StatusOK, pullRequest)
+}
+
+func (gh *FakeGitHub) listPullRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	owner, branch := parseFakeGitHubHead(query.Get("head"))
+	result := []*fakePullRequest{}
+	for _, pullRequest := range gh.pullRequests {
+		if pullRequest.State == "open" && pullRequest.owner == owner && pullRequest.Head.Ref == branch && pullRequest.Base.Ref == query.Get("base") {
+			result = append(result, pullRequest)
+		}
+	}
+	writeFakeGitHubJSON(w, http.StatusOK, result)
+}
+
+// mergePullRequest squash-merges the branch of the pull request with the given number in the origin repo.
+func (gh *FakeGitHub) mergePullRequest(w http.ResponseWriter, r *http.Request, number int) {
+	if number < 1 || number > len(gh.pullRequests) || gh.pullRequests[number-1].State != "open" {
+		http.Error(w, fmt.Sprintf("pull request #%d is not open", number), http.StatusMethodNotAllowed)
+		return
+	}
+	pullRequest := gh.pullRequests[number-1]
+	var request struct {
+		CommitMessage string `json:"commit_message"`
+		CommitTitle   string `json:"commit_title"`
+		MergeMethod   string `json:"merge_method"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.MergeMethod != "squash" {
+		http.Error(w, fmt.Sprintf("FakeGitHub doesn't support the %q merge method", request.MergeMethod), http.StatusMethodNotAllowed)
+		return
+	}
+	message := request.CommitTitle
+	if request.CommitMessage != "" {
+		message += "\n\n" + request.CommitMessage
+	}
+	head := pullRequest.Head.Ref
+	if pullRequest.owner != fakeGitHubOwner {
+		// the branch lives in the fork
+		_, err = gh.repo.Run("git", "fetch", gh.fork.WorkingDir(), "refs/heads/"+pullRequest.Head.Ref)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		head = "FETCH_HEAD"
+	}
+	// the branch is in sync with its target branch, so the squash commit contains the tree of the branch
+	res, err := gh.repo.Run("git", "commit-tree", head+"^{tree}", "-p", pullRequest.Base.Ref, "-m", message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sha := res.OutputSanitized()
+	_, err = gh.repo.Run("git", "update-ref", "refs/heads/"+pullRequest.Base.Ref, sha)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	pullRequest.State = "closed"
+	writeFakeGitHubJSON(w, http.StatusOK, map[string]interface{}{"sha": sha, "merged": true})
+}
+
+// parseFakeGitHubHead provides the organization and branch name of the given head of a pull request.
+// Heads of pull requests from forks have the format "owner:branch".
+func parseFakeGitHubHead(head string) (owner, branch string) {
+	parts := strings.SplitN(head, ":", 2)
+	if len(parts) == 1 {
+		return fakeGitHubOwner, head
+	}
+	return parts[0], parts[1]
+}
+
+func writeFakeGitHubJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
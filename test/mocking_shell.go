@@ -33,6 +33,9 @@ type MockingShell struct {
 	// optional content of the GIT_TOWN_REMOTE environment variable
 	testOrigin string `exhaustruct:"optional"`
 
+	// optional content of the GIT_TOWN_UPSTREAM environment variable
+	testUpstream string `exhaustruct:"optional"`
+
 	// indicates whether the current test has created the binDir
 	usesBinDir bool `exhaustruct:"optional"`
 
@@ -124,6 +127,21 @@ func (ms *MockingShell) MockCommitMessage(message string) error {
 	return ms.createMockBinary(ms.gitEditor, fmt.Sprintf("#!/usr/bin/env bash\n\necho %q > $1", message))
 }
 
+// MockCommitMessages sets up this shell with an editor that enters the given commit messages,
+// one per invocation of the editor.
+func (ms *MockingShell) MockCommitMessages(messages []string) error {
+	if err := ms.createBinDir(); err != nil {
+		return err
+	}
+	messagesPath := filepath.Join(ms.binDir, "commit_messages")
+	err := os.WriteFile(messagesPath, []byte(strings.Join(messages, "\n")+"\n"), 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot write commit messages: %w", err)
+	}
+	ms.gitEditor = "git_editor"
+	return ms.createMockBinary(ms.gitEditor, fmt.Sprintf("#!/usr/bin/env bash\n\nhead -n 1 %q > $1\nsed -i 1d %q", messagesPath, messagesPath))
+}
+
 // MockNoCommandsInstalled pretends that no commands are installed.
 func (ms *MockingShell) MockNoCommandsInstalled() error {
 	content := "#!/usr/bin/env bash\n\nexit 1\n"
@@ -177,6 +195,9 @@ func (ms *MockingShell) RunWith(opts *run.Options, cmd string, args ...string) (
 	// create an environment with the temp shell overrides directory added to the PATH
 	if opts.Env == nil {
 		opts.Env = os.Environ()
+		// ignore the API tokens in the environment of the developer
+		opts.Env = envvars.Replace(opts.Env, "GITHUB_TOKEN", "")
+		opts.Env = envvars.Replace(opts.Env, "GITLAB_TOKEN", "")
 	}
 	// set HOME to the given global directory so that Git puts the global configuration there.
 	opts.Env = envvars.Replace(opts.Env, "HOME", ms.homeDir)
@@ -184,6 +205,10 @@ func (ms *MockingShell) RunWith(opts *run.Options, cmd string, args ...string) (
 	if ms.testOrigin != "" {
 		opts.Env = envvars.Replace(opts.Env, "GIT_TOWN_REMOTE", ms.testOrigin)
 	}
+	// add the custom upstream
+	if ms.testUpstream != "" {
+		opts.Env = envvars.Replace(opts.Env, "GIT_TOWN_UPSTREAM", ms.testUpstream)
+	}
 	// add the custom bin dir to the PATH
 	if ms.usesBinDir {
 		opts.Env = envvars.PrependPath(opts.Env, ms.binDir)
@@ -210,3 +235,8 @@ func (ms *MockingShell) RunWith(opts *run.Options, cmd string, args ...string) (
 func (ms *MockingShell) SetTestOrigin(content string) {
 	ms.testOrigin = content
 }
+
+// SetTestUpstream makes subsequent runs of commands use the given URL for the upstream remote.
+func (ms *MockingShell) SetTestUpstream(content string) {
+	ms.testUpstream = content
+}
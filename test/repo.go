@@ -57,6 +57,7 @@ func NewRepo(workingDir, homeDir, binDir string) Repo {
 		Shell:              &shell,
 		Config:             config.NewGitTown(&shell),
 		DryRun:             &git.DryRun{},
+		GitDirCache:        &cache.String{},
 		IsRepoCache:        &cache.Bool{},
 		RemoteBranchCache:  &cache.Strings{},
 		RemotesCache:       &cache.Strings{},
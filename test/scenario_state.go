@@ -14,6 +14,9 @@ type ScenarioState struct {
 	// the GitEnvironment used in the current scenario
 	gitEnv GitEnvironment
 
+	// the simulated GitHub API of the current scenario, nil if the scenario doesn't use it
+	fakeGitHub *FakeGitHub
+
 	// initialLocalBranches contains the local branches before the WHEN steps run
 	initialLocalBranches []string
 
@@ -48,6 +51,7 @@ type ScenarioState struct {
 // Reset restores the null value of this ScenarioState.
 func (state *ScenarioState) Reset(gitEnv GitEnvironment) {
 	state.gitEnv = gitEnv
+	state.fakeGitHub = nil
 	state.initialLocalBranches = []string{"main"}
 	state.initialRemoteBranches = []string{"main"}
 	state.initialCommits = nil
@@ -1,10 +1,15 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cucumber/messages-go/v10"
+	"github.com/git-town/git-town/v7/src/config"
 	"github.com/git-town/git-town/v7/test/helpers"
 )
 
@@ -25,6 +30,34 @@ func compareExistingCommits(state *ScenarioState, table *messages.PickleStepArgu
 	return nil
 }
 
+// createLockFile makes the Git Town process with the given ID that runs the given command
+// hold the lock for the developer repo of the given ScenarioState.
+func createLockFile(state *ScenarioState, command string, pid int) error {
+	gitDir, err := state.gitEnv.DevRepo.GitDirectory()
+	if err != nil {
+		return err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(map[string]interface{}{
+		"Command":   command,
+		"Hostname":  hostname,
+		"PID":       pid,
+		"StartTime": time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	lockDir := filepath.Join(gitDir, "git-town")
+	err = os.MkdirAll(lockDir, 0o700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(lockDir, "runstate.lock"), content, 0o600)
+}
+
 // hasTag indicates whether the given feature has a tag with the given name.
 func hasTag(scenario *messages.Pickle, name string) bool {
 	for _, tag := range scenario.GetTags() {
@@ -48,3 +81,21 @@ func tableToInput(table *messages.PickleStepArgument_PickleTable) []string {
 	}
 	return result
 }
+
+// startFakeGitHub simulates the API of the GitHub repository "git-town/git-town" in the given repo
+// via a FakeGitHub server and makes the origin of the given ScenarioState the GitHub repository at the given URL.
+func startFakeGitHub(state *ScenarioState, repo *Repo, originURL string) error {
+	state.fakeGitHub = NewFakeGitHub(repo)
+	state.gitEnv.DevShell.SetTestOrigin(originURL)
+	_, err := state.gitEnv.DevRepo.Config.Storage.SetLocalConfigValue(config.GithubAPIURLKey, state.fakeGitHub.APIURL())
+	return err
+}
+
+// useFakeGitHub makes the origin of the given ScenarioState the GitHub repository "git-town/git-town"
+// and simulates its API via a FakeGitHub server, unless the scenario simulates GitHub already.
+func useFakeGitHub(state *ScenarioState) error {
+	if state.fakeGitHub != nil {
+		return nil
+	}
+	return startFakeGitHub(state, state.gitEnv.OriginRepo, "git@github.com:git-town/git-town.git")
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -67,6 +69,9 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 	})
 
 	suite.AfterScenario(func(scenario *messages.Pickle, e error) {
+		if state.fakeGitHub != nil {
+			state.fakeGitHub.Close()
+		}
 		if e != nil {
 			fmt.Printf("failed scenario, investigate state in %q\n", state.gitEnv.Dir)
 		}
@@ -81,6 +86,43 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.gitEnv.DevRepo.CreateBranch(branch, "main")
 	})
 
+	suite.Step(`^a GitHub pull request for branch "([^"]+)" with title "([^"]+)"$`, func(branch, title string) error {
+		err := useFakeGitHub(state)
+		if err != nil {
+			return err
+		}
+		state.gitEnv.DevRepo.Config.Reload()
+		state.fakeGitHub.AddPullRequest(branch, state.gitEnv.DevRepo.Config.ParentBranch(branch), title)
+		return nil
+	})
+
+	suite.Step(`^a Git Town process that doesn't run anymore left its lock behind$`, func() error {
+		process := exec.Command("git", "--version")
+		err := process.Run()
+		if err != nil {
+			return err
+		}
+		return createLockFile(state, "git town sync", process.Process.Pid)
+	})
+
+	suite.Step(`^another Git Town process runs "([^"]+)"$`, func(command string) error {
+		// the test process stands in for the other Git Town process since it runs until the end of the scenario
+		return createLockFile(state, command, os.Getpid())
+	})
+
+	suite.Step(`^another Git Town process runs "([^"]+)" for (\d+) seconds?$`, func(command string, seconds int) error {
+		process := exec.Command("sleep", strconv.Itoa(seconds))
+		err := process.Start()
+		if err != nil {
+			return err
+		}
+		go func() {
+			// reap the process when it ends so that it doesn't exist anymore
+			_ = process.Wait()
+		}()
+		return createLockFile(state, command, process.Process.Pid)
+	})
+
 	suite.Step(`^a coworker clones the repository$`, func() error {
 		return state.gitEnv.AddCoworkerRepo()
 	})
@@ -217,6 +259,19 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return err
 	})
 
+	suite.Step(`^Git Town has stored this run state:$`, func(content *messages.PickleStepArgument_PickleDocString) error {
+		gitDir, err := state.gitEnv.DevRepo.GitDirectory()
+		if err != nil {
+			return err
+		}
+		runStateDir := filepath.Join(gitDir, "git-town")
+		err = os.MkdirAll(runStateDir, 0o700)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(runStateDir, "runstate.json"), []byte(content.Content), 0o600)
+	})
+
 	suite.Step(`^Git Town is no longer configured$`, func() error {
 		res, err := state.gitEnv.DevRepo.HasGitTownConfigNow()
 		if err != nil {
@@ -289,6 +344,12 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^I run "([^"]*)" and close the editor$`, func(cmd string) error {
+		env := append(os.Environ(), "GIT_EDITOR=true")
+		state.runRes, state.runErr = state.gitEnv.DevShell.RunStringWith(cmd, &run.Options{Env: env})
+		return nil
+	})
+
 	suite.Step(`^I run "([^"]*)" and enter an empty commit message$`, func(cmd string) error {
 		if err := state.gitEnv.DevShell.MockCommitMessage(""); err != nil {
 			return err
@@ -297,7 +358,7 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
-	suite.Step(`^I run "([^"]*)" and enter "([^"]*)" for the commit message$`, func(cmd, message string) error {
+	suite.Step(`^I (?:run|ran) "([^"]*)" and enter(?:ed)? "([^"]*)" for the commit message$`, func(cmd, message string) error {
 		if err := state.gitEnv.DevShell.MockCommitMessage(message); err != nil {
 			return err
 		}
@@ -305,12 +366,30 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^I run "([^"]*)" and enter these commit messages:$`, func(cmd string, input *messages.PickleStepArgument_PickleTable) error {
+		commitMessages := []string{}
+		for _, row := range input.Rows[1:] {
+			commitMessages = append(commitMessages, row.Cells[0].Value)
+		}
+		if err := state.gitEnv.DevShell.MockCommitMessages(commitMessages); err != nil {
+			return err
+		}
+		state.runRes, state.runErr = state.gitEnv.DevShell.RunString(cmd)
+		return nil
+	})
+
 	suite.Step(`^I run "([^"]*)", answer the prompts, and close the next editor:$`, func(cmd string, input *messages.PickleStepArgument_PickleTable) error {
 		env := append(os.Environ(), "GIT_EDITOR=true")
 		state.runRes, state.runErr = state.gitEnv.DevShell.RunStringWith(cmd, &run.Options{Env: env, Input: tableToInput(input)})
 		return nil
 	})
 
+	suite.Step(`^I run "([^"]*)" with "([^"]*)" in the environment$`, func(cmd, envVar string) error {
+		env := append(os.Environ(), envVar)
+		state.runRes, state.runErr = state.gitEnv.DevShell.RunStringWith(cmd, &run.Options{Env: env})
+		return nil
+	})
+
 	suite.Step(`^I run "([^"]+)" in the "([^"]+)" folder$`, func(cmd, folderName string) error {
 		state.runRes, state.runErr = state.gitEnv.DevShell.RunStringWith(cmd, &run.Options{Dir: folderName})
 		return nil
@@ -500,15 +579,37 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return state.gitEnv.OriginRepo.RemoveBranch(name)
 	})
 
+	suite.Step(`^Git Town gets terminated during the next push$`, func() error {
+		hooksDir := filepath.Join(state.gitEnv.DevRepo.WorkingDir(), ".git", "hooks")
+		err := os.MkdirAll(hooksDir, 0o744)
+		if err != nil {
+			return err
+		}
+		// the hook runs in the "git push" process started by Git Town,
+		// it terminates Git Town and then removes itself so that it fires only once
+		script := "#!/bin/sh\nkill -TERM $(ps -o ppid= -p $PPID)\nrm \"$0\"\n"
+		return os.WriteFile(filepath.Join(hooksDir, "pre-push"), []byte(script), 0o744)
+	})
+
 	suite.Step(`^Git setting "color.ui" is "([^"]*)"$`, func(value string) error {
 		return state.gitEnv.DevRepo.Config.SetColorUI(value)
 	})
 
+	suite.Step(`^local Git setting "([^"]*)" is "([^"]*)"$`, func(name, value string) error {
+		_, err := state.gitEnv.DevRepo.Config.Storage.SetLocalConfigValue(name, value)
+		return err
+	})
+
 	suite.Step(`^(?:local )?setting "([^"]*)" is "([^"]*)"$`, func(name, value string) error {
 		_, err := state.gitEnv.DevRepo.Config.Storage.SetLocalConfigValue("git-town."+name, value)
 		return err
 	})
 
+	suite.Step(`^(?:local )?setting "([^"]*)" is:$`, func(name string, value *messages.PickleStepArgument_PickleDocString) error {
+		_, err := state.gitEnv.DevRepo.Config.Storage.SetLocalConfigValue("git-town."+name, value.Content)
+		return err
+	})
+
 	suite.Step(`^global setting "([^"]*)" is "([^"]*)"$`, func(name, value string) error {
 		_, err := state.gitEnv.DevRepo.Config.Storage.SetGlobalConfigValue("git-town."+name, value)
 		return err
@@ -570,6 +671,28 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the body of GitHub pull request #(\d+) is now:$`, func(number int, expected *messages.PickleStepArgument_PickleDocString) error {
+		body, err := state.fakeGitHub.PullRequestBody(number)
+		if err != nil {
+			return err
+		}
+		if body != expected.Content {
+			return fmt.Errorf("mismatching body of pull request #%d:\n\nEXPECTED:\n\n%s\n\nACTUAL:\n\n%s", number, expected.Content, body)
+		}
+		return nil
+	})
+
+	suite.Step(`^the GitHub pull requests are now$`, func(table *messages.PickleStepArgument_PickleTable) error {
+		existing := state.fakeGitHub.PullRequestTable()
+		diff, errCount := existing.EqualGherkin(table)
+		if errCount > 0 {
+			fmt.Printf("\nERROR! Found %d differences in the GitHub pull requests\n\n", errCount)
+			fmt.Println(diff)
+			return fmt.Errorf("mismatching GitHub pull requests found, see the diff above")
+		}
+		return nil
+	})
+
 	suite.Step(`^the branches are now$`, func(table *messages.PickleStepArgument_PickleTable) error {
 		existing, err := state.gitEnv.Branches()
 		if err != nil {
@@ -697,6 +820,21 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the Git directory (contains|does not contain) the run state$`, func(expectation string) error {
+		gitDir, err := state.gitEnv.DevRepo.GitDirectory()
+		if err != nil {
+			return err
+		}
+		runStatePath := filepath.Join(gitDir, "git-town", "runstate.json")
+		_, err = os.Stat(runStatePath)
+		have := err == nil
+		want := expectation == "contains"
+		if have != want {
+			return fmt.Errorf("expected run state file %q to exist: %t, but it exists: %t", runStatePath, want, have)
+		}
+		return nil
+	})
+
 	suite.Step(`^the initial branch hierarchy exists$`, func() error {
 		have := state.gitEnv.DevRepo.BranchHierarchyTable()
 		state.initialBranchHierarchy.Sort()
@@ -830,6 +968,41 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the last commit on the "([^"]*)" branch has the trailers:$`, func(branch string, expected *messages.PickleStepArgument_PickleDocString) error {
+		out, err := state.gitEnv.DevRepo.Run("git", "log", "-1", "--format=%(trailers:only,unfold)", branch)
+		if err != nil {
+			return fmt.Errorf("cannot determine the trailers of the last commit on branch %q: %w", branch, err)
+		}
+		have := out.OutputSanitized()
+		if have != expected.Content {
+			return fmt.Errorf("mismatching trailers:\n\nEXPECTED:\n%s\n\nACTUAL:\n%s", expected.Content, have)
+		}
+		return nil
+	})
+
+	suite.Step(`^the last commit on the "([^"]*)" branch has the message:$`, func(branch string, expected *messages.PickleStepArgument_PickleDocString) error {
+		out, err := state.gitEnv.DevRepo.Run("git", "log", "-1", "--format=%B", branch)
+		if err != nil {
+			return fmt.Errorf("cannot determine the message of the last commit on branch %q: %w", branch, err)
+		}
+		have := out.OutputSanitized()
+		if have != expected.Content {
+			return fmt.Errorf("mismatching commit message:\n\nEXPECTED:\n%s\n\nACTUAL:\n%s", expected.Content, have)
+		}
+		return nil
+	})
+
+	suite.Step(`^the mailmap contains:$`, func(content *messages.PickleStepArgument_PickleDocString) error {
+		// the mailmap lives outside of the repo so that it doesn't show up as an uncommitted file
+		mailmapPath := filepath.Join(state.gitEnv.Dir, "mailmap")
+		err := os.WriteFile(mailmapPath, []byte(content.Content+"\n"), 0o600)
+		if err != nil {
+			return fmt.Errorf("cannot write the mailmap file: %w", err)
+		}
+		_, err = state.gitEnv.DevRepo.Config.Storage.SetLocalConfigValue("mailmap.file", mailmapPath)
+		return err
+	})
+
 	suite.Step(`^the main branch is "([^"]+)"$`, func(name string) error {
 		return state.gitEnv.DevRepo.Config.SetMainBranch(name)
 	})
@@ -852,6 +1025,17 @@ func Steps(suite *godog.Suite, state *ScenarioState) {
 		return nil
 	})
 
+	suite.Step(`^the origin is a fork of the upstream repo on GitHub$`, func() error {
+		// the upstream repo is the GitHub repository "git-town/git-town", the origin is its fork "kevgo/git-town"
+		err := startFakeGitHub(state, state.gitEnv.UpstreamRepo, "git@github.com:kevgo/git-town.git")
+		if err != nil {
+			return err
+		}
+		state.fakeGitHub.SetFork("kevgo", state.gitEnv.OriginRepo)
+		state.gitEnv.DevShell.SetTestUpstream("git@github.com:git-town/git-town.git")
+		return nil
+	})
+
 	suite.Step(`^the perennial branches are "([^"]+)"$`, func(name string) error {
 		return state.gitEnv.DevRepo.Config.AddToPerennialBranches(name)
 	})